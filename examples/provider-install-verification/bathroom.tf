@@ -10,12 +10,6 @@ import {
 }
 
 
-import {
-  for_each = local.bathroom_lights
-  to       = philips_light.bathroom[each.key]
-  id       = each.value
-}
-
 import {
   # Name = Bathroom
   id = "00:17:88:01:0c:d7:89:f9"
@@ -23,9 +17,10 @@ import {
 }
 
 resource "philips_light" "bathroom" {
-  count    = 6
-  name     = "Bathroom ${count.index + 1}"
-  function = "functional"
+  count       = 6
+  name        = "Bathroom ${count.index + 1}"
+  function    = "functional"
+  mac_address = local.bathroom_lights[count.index]
 }
 
 
@@ -36,33 +31,32 @@ resource "philips_room" "bathroom" {
 }
 
 resource "philips_motion" "bathroom" {
-  enabled = true
+  enabled             = true
+  light_level_enabled = true
 }
 
 resource "philips_scene" "bathroom_bright" {
   group = philips_room.bathroom
   name  = "Bright"
-  actions = [
-    for light in philips_light.bathroom : {
-      target_id         = light.id
+  actions = {
+    for light in philips_light.bathroom : light.id => {
       target_type       = "light"
       on                = true
       color_temperature = 2700
       brightness        = 100
     }
-  ]
+  }
 }
 
 resource "philips_scene" "bathroom_cool" {
   group = philips_room.bathroom
   name  = "Bathroom Cool"
-  actions = [
-    for light in philips_light.bathroom : {
-      target_id         = light.id
+  actions = {
+    for light in philips_light.bathroom : light.id => {
       target_type       = "light"
       on                = true
       color_temperature = 6500
       brightness        = 100
     }
-  ]
+  }
 }
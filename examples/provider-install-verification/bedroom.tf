@@ -31,11 +31,29 @@ resource "philips_room" "bedroom" {
 resource "philips_light" "bedroom_left" {
   name     = "Bed Left"
   function = "decorative"
+  powerup = {
+    preset            = "custom"
+    on_mode           = "on"
+    on                = true
+    dimming_mode      = "dimming"
+    brightness        = 10
+    color_mode        = "color_temperature"
+    color_temperature = 2200
+  }
 }
 
 resource "philips_light" "bedroom_right" {
   name     = "Bed Right"
   function = "decorative"
+  powerup = {
+    preset            = "custom"
+    on_mode           = "on"
+    on                = true
+    dimming_mode      = "dimming"
+    brightness        = 10
+    color_mode        = "color_temperature"
+    color_temperature = 2200
+  }
 }
 
 resource "philips_light" "bedroom_valance" {
@@ -59,6 +77,7 @@ module "bedroom_reading" {
     brightness        = 100
     color_temperature = 2200
   }
+  transition_duration = 2000
 }
 
 module "bedroom_day" {
@@ -74,4 +93,18 @@ module "bedroom_day" {
     brightness        = 100
     color_temperature = 6000
   }
-}
\ No newline at end of file
+}
+resource "philips_smart_scene" "bedroom_natural_light" {
+  name  = "Bedroom Natural Light"
+  group = philips_room.bedroom
+  week_timeslots = [
+    {
+      recurrence = ["monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"]
+      timeslots = [
+        { start_time = "07:00", scene_id = module.bedroom_day.scene.id },
+        { start_time = "20:00", scene_id = module.bedroom_reading.scene.id },
+      ]
+    }
+  ]
+  transition_duration = 60000
+}
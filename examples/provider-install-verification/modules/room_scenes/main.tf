@@ -17,21 +17,23 @@ locals {
 resource "philips_scene" "scene" {
   name  = var.name
   group = var.target
-  actions = concat([
-    for light in local.enabled_act : {
-      target_id         = light.id
+  actions = merge({
+    for light in local.enabled_act : light.id => {
       target_type       = light.type
       on                = true
       brightness        = var.light_setting.brightness
       color_temperature = var.light_setting.color_temperature
     }
-    ], [
-    for light in local.disabled_lights : {
-      target_id         = light.id
+    }, {
+    for light in local.disabled_lights : light.id => {
       target_type       = light.type
       on                = false
       brightness        = var.light_setting.brightness
       color_temperature = var.light_setting.color_temperature
     }
-  ])
+  })
+
+  recall = var.transition_duration == null ? null : {
+    duration = var.transition_duration
+  }
 }
\ No newline at end of file
@@ -34,4 +34,9 @@ variable "target" {
     type = string
   })
   description = "The target room or zone for the scene"
-}
\ No newline at end of file
+}
+variable "transition_duration" {
+  type        = number
+  default     = null
+  description = "The transition duration in milliseconds when the scene is recalled. The scene snaps to its settings when unset."
+}
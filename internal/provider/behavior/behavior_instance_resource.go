@@ -0,0 +1,322 @@
+package behavior
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/behavior_instance"
+	"github.com/richseviora/huego/pkg/resources/behavior_script"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"reflect"
+	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+var (
+	_ resource.Resource                   = &BehaviorInstanceResource{}
+	_ resource.ResourceWithConfigure      = &BehaviorInstanceResource{}
+	_ resource.ResourceWithImportState    = &BehaviorInstanceResource{}
+	_ resource.ResourceWithValidateConfig = &BehaviorInstanceResource{}
+	_ resource.ResourceWithModifyPlan     = &BehaviorInstanceResource{}
+)
+
+func NewBehaviorInstanceResource() resource.Resource {
+	return &BehaviorInstanceResource{}
+}
+
+// BehaviorInstanceResource manages a behavior instance of any behavior script, with the configuration as JSON.
+type BehaviorInstanceResource struct {
+	client device.ClientWithLightIDCache
+}
+
+type BehaviorInstanceResourceModel struct {
+	ID            types.String  `tfsdk:"id"`
+	ScriptID      types.String  `tfsdk:"script_id"`
+	ScriptName    types.String  `tfsdk:"script_name"`
+	Name          types.String  `tfsdk:"name"`
+	Enabled       types.Bool    `tfsdk:"enabled"`
+	Configuration huetypes.JSON `tfsdk:"configuration"`
+}
+
+func (b *BehaviorInstanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_behavior_instance"
+}
+
+func (b *BehaviorInstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Philips Hue behavior instance for any behavior script, such as Wake up, Go to sleep, Timers or Coming home. The configuration is validated against the configuration schema of the script at plan time.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"script_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the behavior script. Exactly one of `script_id` or `script_name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("script_name")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"script_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the behavior script, for example `Wake up` or `Timers`. The instance is replaced when the name resolves to a different script.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the behavior instance.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the behavior instance is enabled. Defaults to `true`.",
+			},
+			"configuration": schema.StringAttribute{
+				Required:    true,
+				CustomType:  huetypes.JSONType{},
+				Description: "The configuration of the behavior instance as a JSON object, for example from `jsonencode`. Differences in formatting or key order, and properties the bridge adds with a default of the script, are ignored.",
+			},
+		},
+	}
+}
+
+func (b *BehaviorInstanceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(device.ClientWithLightIDCache)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected device.ClientWithLightIDCache, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	b.client = client
+}
+
+func (b *BehaviorInstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configuration huetypes.JSON
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("configuration"), &configuration)...)
+	if resp.Diagnostics.HasError() || configuration.IsNull() || configuration.IsUnknown() {
+		return
+	}
+	var object map[string]any
+	if err := configuration.Unmarshal(&object); err != nil || object == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("configuration"), "Invalid Behavior Instance Configuration",
+			"The configuration must be a JSON object.")
+	}
+}
+
+func (b *BehaviorInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || b.client == nil {
+		return
+	}
+	var data BehaviorInstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.ScriptName.IsUnknown() || data.ScriptName.IsNull() && data.ScriptID.IsUnknown() {
+		return
+	}
+
+	script, err := b.findScript(data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading behavior script",
+			"Could not find the behavior script: "+err.Error())
+		return
+	}
+	if !data.ScriptName.IsNull() {
+		// The ID is resolved again so that a name of a different script replaces the instance. The plan modifiers of
+		// script_id already ran, so the replacement is required here. Comparing the IDs keeps an imported instance,
+		// which has no script name in its state, in place.
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("script_id"), script.ID)...)
+		if !req.State.Raw.IsNull() {
+			var stateScriptID types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("script_id"), &stateScriptID)...)
+			if !stateScriptID.IsNull() && stateScriptID.ValueString() != script.ID {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("script_id"))
+			}
+		}
+	}
+
+	if data.Configuration.IsUnknown() || len(script.ConfigurationSchema) == 0 {
+		return
+	}
+	var configuration any
+	if err := data.Configuration.Unmarshal(&configuration); err != nil {
+		// Reported by ValidateConfig.
+		return
+	}
+	violations, err := ValidateConfiguration(script.ConfigurationSchema, configuration)
+	if err != nil {
+		// An unsupported schema must not prevent the plan, the bridge still validates the configuration.
+		resp.Diagnostics.AddAttributeWarning(path.Root("configuration"), "Behavior Instance Not Validated",
+			"Could not validate the configuration against the "+script.Metadata.Name+" behavior script: "+err.Error())
+		return
+	}
+	for _, violation := range violations {
+		resp.Diagnostics.AddAttributeError(path.Root("configuration"), "Invalid Behavior Instance Configuration",
+			"The "+script.Metadata.Name+" behavior script rejects the configuration: "+violation)
+	}
+}
+
+// findScript returns the behavior script by its name when it is configured, or by its ID otherwise.
+func (b *BehaviorInstanceResource) findScript(data BehaviorInstanceResourceModel) (behavior_script.Data, error) {
+	if !data.ScriptName.IsNull() && !data.ScriptName.IsUnknown() {
+		return b.client.GetBehaviorScriptForMetadataName(data.ScriptName.ValueString())
+	}
+	return b.client.GetBehaviorScript(data.ScriptID.ValueString())
+}
+
+func (b *BehaviorInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BehaviorInstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	script, err := b.findScript(data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating behavior instance",
+			"Could not find the behavior script: "+err.Error(),
+		)
+		return
+	}
+	response, err := b.client.BehaviorInstanceService().CreateRawBehaviorInstance(ctx, behavior_instance.RawCreateRequest{
+		ScriptID:      script.ID,
+		Configuration: json.RawMessage(data.Configuration.ValueString()),
+		Enabled:       data.Enabled.ValueBool(),
+		Metadata:      &behavior_instance.Metadata{Name: data.Name.ValueString()},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating behavior instance",
+			"Could not create behavior instance: "+err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(response.RID)
+	data.ScriptID = types.StringValue(script.ID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BehaviorInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BehaviorInstanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instance, err := b.client.BehaviorInstanceService().GetRawBehaviorInstance(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading behavior instance",
+			"Could not read behavior instance ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	// Without the script, the configuration is compared in full.
+	script, _ := b.client.GetBehaviorScript(instance.ScriptID)
+	setModelFromRawData(&data, *instance, script.ConfigurationSchema)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setModelFromRawData sets the attributes read from the bridge. The script name is kept as configured, as the
+// bridge only stores the script ID. The schema is the configuration schema of the script, if any.
+func setModelFromRawData(data *BehaviorInstanceResourceModel, instance behavior_instance.RawData, schema json.RawMessage) {
+	data.ID = types.StringValue(instance.ID)
+	data.ScriptID = types.StringValue(instance.ScriptID)
+	data.Name = types.StringValue(instance.Metadata.Name)
+	data.Enabled = types.BoolValue(instance.Enabled)
+	data.Configuration = readConfiguration(data.Configuration, instance.Configuration, schema)
+}
+
+// readConfiguration keeps the configured value when the configuration read from the bridge only adds properties
+// that the script schema declares with a default. Any other difference returns the configuration of the bridge,
+// including a property the bridge still has after it was removed from the configuration.
+func readConfiguration(configured huetypes.JSON, read json.RawMessage, schema json.RawMessage) huetypes.JSON {
+	bridge := huetypes.NewJSONValue(string(read))
+	if configured.IsNull() || configured.IsUnknown() || len(schema) == 0 {
+		return bridge
+	}
+	var readValue, configuredValue any
+	if err := json.Unmarshal(read, &readValue); err != nil {
+		return bridge
+	}
+	if err := configured.Unmarshal(&configuredValue); err != nil {
+		return bridge
+	}
+	trimmed, err := RemoveDefaultProperties(schema, readValue, configuredValue)
+	if err != nil || !reflect.DeepEqual(trimmed, configuredValue) {
+		return bridge
+	}
+	return configured
+}
+
+func (b *BehaviorInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BehaviorInstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := b.client.BehaviorInstanceService().UpdateRawBehaviorInstance(ctx, data.ID.ValueString(), behavior_instance.RawUpdateRequest{
+		Configuration: json.RawMessage(data.Configuration.ValueString()),
+		Enabled:       data.Enabled.ValueBoolPointer(),
+		Metadata:      &behavior_instance.Metadata{Name: data.Name.ValueString()},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating behavior instance",
+			"Could not update behavior instance ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (b *BehaviorInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BehaviorInstanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := b.client.BehaviorInstanceService().DeleteBehaviorInstance(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting behavior instance",
+			"Could not delete behavior instance ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (b *BehaviorInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,228 @@
+package behavior
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/richseviora/huego/pkg/resources/behavior_instance"
+	"github.com/richseviora/huego/pkg/resources/behavior_script"
+	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+// A Coming home behavior instance as returned by the bridge.
+const comingHomeJSON = `{
+	"id": "2f5d8e1a-6b3c-4d7e-9f10-a1b2c3d4e5f6",
+	"type": "behavior_instance",
+	"script_id": "fd60fcd1-4809-4813-b510-4a18856a595c",
+	"enabled": true,
+	"configuration": {"when": {"timeslots": [{"start_time": {"kind": "sunset"}, "recall": {"rid": "c1d2e3f4-0000-4000-8000-000000000001", "rtype": "scene"}}]}, "where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}]},
+	"metadata": {"name": "Welcome home"}
+}`
+
+func TestSetModelFromRawData(t *testing.T) {
+	var instance behavior_instance.RawData
+	if err := json.Unmarshal([]byte(comingHomeJSON), &instance); err != nil {
+		t.Fatalf("could not unmarshal bridge response: %v", err)
+	}
+	data := BehaviorInstanceResourceModel{
+		ScriptName: types.StringValue("Coming home"),
+		// The configuration as written in Terraform, with a different key order and formatting.
+		Configuration: huetypes.NewJSONValue(`{
+			"where": [{"group": {"rtype": "room", "rid": "d4e5f6a7-0000-4000-8000-000000000003"}}],
+			"when": {"timeslots": [{"recall": {"rtype": "scene", "rid": "c1d2e3f4-0000-4000-8000-000000000001"}, "start_time": {"kind": "sunset"}}]}
+		}`),
+	}
+	configured := data.Configuration
+
+	setModelFromRawData(&data, instance, nil)
+
+	if data.ID.ValueString() != "2f5d8e1a-6b3c-4d7e-9f10-a1b2c3d4e5f6" || data.ScriptID.ValueString() != "fd60fcd1-4809-4813-b510-4a18856a595c" {
+		t.Errorf("unexpected IDs %v and %v", data.ID, data.ScriptID)
+	}
+	if data.ScriptName.ValueString() != "Coming home" {
+		t.Errorf("expected the configured script name to be kept, got %v", data.ScriptName)
+	}
+	if data.Name.ValueString() != "Welcome home" || !data.Enabled.ValueBool() {
+		t.Errorf("unexpected name %v or enabled %v", data.Name, data.Enabled)
+	}
+	equal, diags := configured.StringSemanticEquals(context.Background(), data.Configuration)
+	if diags.HasError() || !equal {
+		t.Errorf("expected the configuration read back to equal the configured one, got %s", data.Configuration.ValueString())
+	}
+}
+
+// A configuration schema that declares defaults for the fade in duration and the offset of a start time.
+const defaultsSchema = `{
+	"type": "object",
+	"properties": {
+		"fade_in_duration": {"$ref": "#/definitions/duration"},
+		"where": {"type": "array", "items": {"type": "object", "properties": {"group": {"type": "object"}, "items": {"type": "array"}}}},
+		"when": {
+			"type": "object",
+			"properties": {
+				"timeslots": {
+					"type": "array",
+					"items": {
+						"type": "object",
+						"properties": {
+							"start_time": {
+								"type": "object",
+								"properties": {"kind": {"type": "string"}, "offset": {"type": "object", "default": {"minutes": 0}}}
+							}
+						}
+					}
+				}
+			}
+		}
+	},
+	"definitions": {"duration": {"type": "object", "default": {"seconds": 60}}}
+}`
+
+func TestSetModelFromRawDataAddedFields(t *testing.T) {
+	configured := `{"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}], "when": {"timeslots": [{"start_time": {"kind": "sunset"}}]}}`
+	tests := []struct {
+		name          string
+		configuration string
+		schema        string
+		wantKept      bool
+	}{
+		{
+			name:          "bridge adds defaults",
+			configuration: `{"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}], "when": {"timeslots": [{"start_time": {"kind": "sunset", "offset": {"minutes": 0}}}]}, "fade_in_duration": {"seconds": 60}}`,
+			schema:        defaultsSchema,
+			wantKept:      true,
+		},
+		{
+			name:          "bridge adds defaults without a schema",
+			configuration: `{"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}], "when": {"timeslots": [{"start_time": {"kind": "sunset"}}]}, "fade_in_duration": {"seconds": 60}}`,
+		},
+		{
+			name:          "bridge keeps a removed field without a default",
+			configuration: `{"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}, "items": []}], "when": {"timeslots": [{"start_time": {"kind": "sunset"}}]}}`,
+			schema:        defaultsSchema,
+		},
+		{
+			name:          "bridge changes a field",
+			configuration: `{"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}], "when": {"timeslots": [{"start_time": {"kind": "sunrise"}}]}}`,
+			schema:        defaultsSchema,
+		},
+		{
+			name:          "bridge removes a field",
+			configuration: `{"when": {"timeslots": [{"start_time": {"kind": "sunset"}}]}}`,
+			schema:        defaultsSchema,
+		},
+		{
+			name:          "bridge adds an array element",
+			configuration: `{"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}], "when": {"timeslots": [{"start_time": {"kind": "sunset"}}, {"start_time": {"kind": "sunrise"}}]}}`,
+			schema:        defaultsSchema,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := BehaviorInstanceResourceModel{Configuration: huetypes.NewJSONValue(configured)}
+			var schema json.RawMessage
+			if tt.schema != "" {
+				schema = json.RawMessage(tt.schema)
+			}
+			setModelFromRawData(&data, behavior_instance.RawData{Configuration: json.RawMessage(tt.configuration)}, schema)
+			want := tt.configuration
+			if tt.wantKept {
+				want = configured
+			}
+			if data.Configuration.ValueString() != want {
+				t.Errorf("setModelFromRawData() configuration = %s, want %s", data.Configuration.ValueString(), want)
+			}
+		})
+	}
+}
+
+// scriptClient is a client that only serves behavior scripts.
+type scriptClient struct {
+	device.ClientWithLightIDCache
+	scripts []behavior_script.Data
+}
+
+func (c *scriptClient) GetBehaviorScriptForMetadataName(name string) (behavior_script.Data, error) {
+	for _, script := range c.scripts {
+		if script.Metadata.Name == name {
+			return script, nil
+		}
+	}
+	return behavior_script.Data{}, errors.New("could not find behavior script: " + name)
+}
+
+func (c *scriptClient) GetBehaviorScript(id string) (behavior_script.Data, error) {
+	for _, script := range c.scripts {
+		if script.ID == id {
+			return script, nil
+		}
+	}
+	return behavior_script.Data{}, errors.New("could not find behavior script: " + id)
+}
+
+func TestBehaviorInstanceModifyPlanScriptName(t *testing.T) {
+	ctx := context.Background()
+	b := &BehaviorInstanceResource{client: &scriptClient{scripts: []behavior_script.Data{
+		{ID: "wake-up", Metadata: behavior_script.Metadata{Name: "Wake up"}},
+		{ID: "go-to-sleep", Metadata: behavior_script.Metadata{Name: "Go to sleep"}},
+	}}}
+	schemaResp := &resource.SchemaResponse{}
+	b.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// The state of an instance after it was imported, which has no script name.
+	imported := BehaviorInstanceResourceModel{
+		ID:            types.StringValue("instance"),
+		ScriptID:      types.StringValue("wake-up"),
+		ScriptName:    types.StringNull(),
+		Name:          types.StringValue("Morning"),
+		Enabled:       types.BoolValue(true),
+		Configuration: huetypes.NewJSONValue(`{}`),
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	if diags := state.Set(ctx, &imported); diags.HasError() {
+		t.Fatalf("could not set the state: %v", diags)
+	}
+
+	tests := []struct {
+		name        string
+		scriptName  string
+		wantID      string
+		wantReplace bool
+	}{
+		{name: "same script", scriptName: "Wake up", wantID: "wake-up"},
+		{name: "different script", scriptName: "Go to sleep", wantID: "go-to-sleep", wantReplace: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planned := imported
+			planned.ScriptName = types.StringValue(tt.scriptName)
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			if diags := plan.Set(ctx, &planned); diags.HasError() {
+				t.Fatalf("could not set the plan: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			b.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan() errors = %v", resp.Diagnostics)
+			}
+			var scriptID types.String
+			resp.Plan.GetAttribute(ctx, path.Root("script_id"), &scriptID)
+			if scriptID.ValueString() != tt.wantID {
+				t.Errorf("ModifyPlan() script_id = %s, want %s", scriptID.ValueString(), tt.wantID)
+			}
+			if (len(resp.RequiresReplace) > 0) != tt.wantReplace {
+				t.Errorf("ModifyPlan() requires replace = %v, want %v", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
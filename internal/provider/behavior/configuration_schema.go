@@ -0,0 +1,347 @@
+package behavior
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchema is the subset of JSON Schema used by the configuration_schema of behavior scripts.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 json.RawMessage        `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []any                  `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	AllOf                []*jsonSchema          `json:"allOf"`
+	AnyOf                []*jsonSchema          `json:"anyOf"`
+	OneOf                []*jsonSchema          `json:"oneOf"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+	Default              json.RawMessage        `json:"default"`
+}
+
+func (s *jsonSchema) types() ([]string, error) {
+	if len(s.Type) == 0 {
+		return nil, nil
+	}
+	var single string
+	if err := json.Unmarshal(s.Type, &single); err == nil {
+		return []string{single}, nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(s.Type, &multiple); err != nil {
+		return nil, fmt.Errorf("invalid type %s", string(s.Type))
+	}
+	return multiple, nil
+}
+
+type schemaValidator struct {
+	root *jsonSchema
+	err  error
+}
+
+// ValidateConfiguration validates a behavior instance configuration against the configuration_schema of its
+// behavior script. It returns a message for every violation, prefixed with the path of the offending value.
+// An error is only returned when the schema itself cannot be used.
+func ValidateConfiguration(schema json.RawMessage, configuration any) ([]string, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("could not parse configuration schema: %w", err)
+	}
+	// Round trip the configuration so that structs are validated the same way as the JSON sent to the bridge.
+	encoded, err := json.Marshal(configuration)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode configuration: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, fmt.Errorf("could not decode configuration: %w", err)
+	}
+
+	v := &schemaValidator{root: &root}
+	violations := v.validate(&root, value, "configuration", nil)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return violations, nil
+}
+
+func (v *schemaValidator) resolve(ref string) *jsonSchema {
+	for _, prefix := range []string{"#/definitions/", "#/$defs/"} {
+		name, ok := strings.CutPrefix(ref, prefix)
+		if !ok {
+			continue
+		}
+		if s, ok := v.root.Definitions[name]; ok {
+			return s
+		}
+		if s, ok := v.root.Defs[name]; ok {
+			return s
+		}
+	}
+	if ref == "#" {
+		return v.root
+	}
+	v.err = fmt.Errorf("could not resolve configuration schema reference %q", ref)
+	return nil
+}
+
+// follow follows the references of the schema, and adds them to the references already followed for the same value.
+func (v *schemaValidator) follow(s *jsonSchema, refs *[]string) *jsonSchema {
+	for s != nil && s.Ref != "" {
+		if slices.Contains(*refs, s.Ref) {
+			v.err = fmt.Errorf("circular configuration schema reference %q", s.Ref)
+			return nil
+		}
+		*refs = append(*refs, s.Ref)
+		s = v.resolve(s.Ref)
+	}
+	return s
+}
+
+// validate validates the value against the schema. The refs are the references already followed for this value,
+// a reference that is followed twice for the same value would recurse forever.
+func (v *schemaValidator) validate(s *jsonSchema, value any, path string, refs []string) []string {
+	if s == nil || v.err != nil {
+		return nil
+	}
+	if s = v.follow(s, &refs); s == nil {
+		return nil
+	}
+
+	types, err := s.types()
+	if err != nil {
+		v.err = err
+		return nil
+	}
+	if len(types) > 0 && !matchesAnyType(types, value) {
+		return []string{fmt.Sprintf("%s: must be of type %s", path, strings.Join(types, " or "))}
+	}
+
+	var violations []string
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		violations = append(violations, fmt.Sprintf("%s: must be one of %s", path, formatValues(s.Enum)))
+	}
+
+	switch value := value.(type) {
+	case float64:
+		if s.Minimum != nil && value < *s.Minimum {
+			violations = append(violations, fmt.Sprintf("%s: must be at least %v", path, *s.Minimum))
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			violations = append(violations, fmt.Sprintf("%s: must be at most %v", path, *s.Maximum))
+		}
+	case string:
+		length := utf8.RuneCountInString(value)
+		if s.MinLength != nil && length < *s.MinLength {
+			violations = append(violations, fmt.Sprintf("%s: must be at least %d characters", path, *s.MinLength))
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			violations = append(violations, fmt.Sprintf("%s: must be at most %d characters", path, *s.MaxLength))
+		}
+	case []any:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			violations = append(violations, fmt.Sprintf("%s: must have at least %d items", path, *s.MinItems))
+		}
+		if s.MaxItems != nil && len(value) > *s.MaxItems {
+			violations = append(violations, fmt.Sprintf("%s: must have at most %d items", path, *s.MaxItems))
+		}
+		for i, item := range value {
+			violations = append(violations, v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i), nil)...)
+		}
+	case map[string]any:
+		violations = append(violations, v.validateObject(s, value, path)...)
+	}
+
+	for _, sub := range s.AllOf {
+		violations = append(violations, v.validate(sub, value, path, refs)...)
+	}
+	if len(s.AnyOf) > 0 && v.countMatches(s.AnyOf, value, path, refs) == 0 {
+		violations = append(violations, fmt.Sprintf("%s: must match at least one of the allowed forms", path))
+	}
+	if len(s.OneOf) > 0 && v.countMatches(s.OneOf, value, path, refs) != 1 {
+		violations = append(violations, fmt.Sprintf("%s: must match exactly one of the allowed forms", path))
+	}
+	return violations
+}
+
+func (v *schemaValidator) validateObject(s *jsonSchema, value map[string]any, path string) []string {
+	var violations []string
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			violations = append(violations, fmt.Sprintf("%s: missing required property %q", path, name))
+		}
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property, ok := s.Properties[name]; ok {
+			violations = append(violations, v.validate(property, value[name], path+"."+name, nil)...)
+			continue
+		}
+		if string(s.AdditionalProperties) == "false" {
+			violations = append(violations, fmt.Sprintf("%s: unknown property %q", path, name))
+			continue
+		}
+		if len(s.AdditionalProperties) > 0 && string(s.AdditionalProperties) != "true" {
+			var additional jsonSchema
+			if err := json.Unmarshal(s.AdditionalProperties, &additional); err != nil {
+				v.err = fmt.Errorf("invalid additionalProperties: %w", err)
+				return nil
+			}
+			violations = append(violations, v.validate(&additional, value[name], path+"."+name, nil)...)
+		}
+	}
+	return violations
+}
+
+func (v *schemaValidator) countMatches(schemas []*jsonSchema, value any, path string, refs []string) int {
+	matches := 0
+	for _, sub := range schemas {
+		if len(v.validate(sub, value, path, refs)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// RemoveDefaultProperties returns the configuration read from the bridge without the object properties that are
+// missing from the configured value and that the schema declares with a default, as the bridge adds those when it
+// stores a configuration. Arrays are only compared item by item when they have the same length.
+func RemoveDefaultProperties(schema json.RawMessage, read, configured any) (any, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("could not parse configuration schema: %w", err)
+	}
+	v := &schemaValidator{root: &root}
+	trimmed := v.removeDefaults(&root, read, configured, nil)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return trimmed, nil
+}
+
+func (v *schemaValidator) removeDefaults(s *jsonSchema, read, configured any, refs []string) any {
+	if s = v.follow(s, &refs); s == nil {
+		return read
+	}
+	switch read := read.(type) {
+	case map[string]any:
+		configured, ok := configured.(map[string]any)
+		if !ok {
+			return read
+		}
+		trimmed := make(map[string]any, len(read))
+		for name, value := range read {
+			property := v.property(s, name, refs)
+			if configuredValue, ok := configured[name]; ok {
+				trimmed[name] = v.removeDefaults(property, value, configuredValue, nil)
+				continue
+			}
+			if property = v.follow(property, &[]string{}); property == nil || len(property.Default) == 0 {
+				trimmed[name] = value
+			}
+		}
+		return trimmed
+	case []any:
+		configured, ok := configured.([]any)
+		if !ok || len(configured) != len(read) {
+			return read
+		}
+		trimmed := make([]any, len(read))
+		for i := range read {
+			trimmed[i] = v.removeDefaults(s.Items, read[i], configured[i], nil)
+		}
+		return trimmed
+	}
+	return read
+}
+
+// property returns the schema of an object property, which may also be declared by the allOf, anyOf or oneOf
+// schemas of the object.
+func (v *schemaValidator) property(s *jsonSchema, name string, refs []string) *jsonSchema {
+	if s = v.follow(s, &refs); s == nil {
+		return nil
+	}
+	if property, ok := s.Properties[name]; ok {
+		return property
+	}
+	for _, subs := range [][]*jsonSchema{s.AllOf, s.AnyOf, s.OneOf} {
+		for _, sub := range subs {
+			if property := v.property(sub, name, refs); property != nil {
+				return property
+			}
+		}
+	}
+	return nil
+}
+
+func matchesAnyType(types []string, value any) bool {
+	for _, t := range types {
+		if matchesType(t, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(t string, value any) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		encoded, _ := json.Marshal(value)
+		formatted[i] = string(encoded)
+	}
+	return strings.Join(formatted, ", ")
+}
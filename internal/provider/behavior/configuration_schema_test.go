@@ -0,0 +1,158 @@
+package behavior
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// A trimmed down configuration_schema of the Motion Sensor behavior script.
+const motionSensorSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"required": ["source", "when", "where"],
+	"additionalProperties": false,
+	"properties": {
+		"source": {"$ref": "#/definitions/reference"},
+		"settings": {
+			"type": "object",
+			"properties": {
+				"daylight_sensitivity": {
+					"type": "object",
+					"properties": {
+						"dark_threshold": {"type": "integer", "minimum": 0, "maximum": 65535},
+						"offset": {"type": "integer", "minimum": 0, "maximum": 65535}
+					}
+				}
+			}
+		},
+		"when": {
+			"type": "object",
+			"properties": {
+				"timeslots": {
+					"type": "array",
+					"minItems": 1,
+					"items": {
+						"type": "object",
+						"properties": {
+							"on_no_motion": {
+								"type": "object",
+								"properties": {
+									"recall_single": {
+										"type": "array",
+										"items": {
+											"type": "object",
+											"properties": {"action": {"enum": ["all_off", "previous_state"]}}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		},
+		"where": {"type": "array", "items": {"type": "object", "properties": {"group": {"$ref": "#/definitions/reference"}}}}
+	},
+	"definitions": {
+		"reference": {
+			"type": "object",
+			"required": ["rid", "rtype"],
+			"properties": {
+				"rid": {"type": "string", "minLength": 1},
+				"rtype": {"type": "string"}
+			}
+		}
+	}
+}`
+
+func TestValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration string
+		want          []string
+	}{
+		{
+			name: "valid",
+			configuration: `{
+				"source": {"rid": "sensor", "rtype": "motion"},
+				"settings": {"daylight_sensitivity": {"dark_threshold": 12000, "offset": 7000}},
+				"when": {"timeslots": [{"on_no_motion": {"recall_single": [{"action": "all_off"}]}}]},
+				"where": [{"group": {"rid": "room", "rtype": "room"}}]
+			}`,
+		},
+		{
+			name: "violations",
+			configuration: `{
+				"source": {"rid": "", "rtype": "motion"},
+				"settings": {"daylight_sensitivity": {"dark_threshold": 70000, "offset": 1.5}},
+				"when": {"timeslots": [{"on_no_motion": {"recall_single": [{"action": "all_of"}]}}]},
+				"where": [{"group": {"rid": "room"}}],
+				"unknown": true
+			}`,
+			want: []string{
+				`configuration.settings.daylight_sensitivity.dark_threshold: must be at most 65535`,
+				`configuration.settings.daylight_sensitivity.offset: must be of type integer`,
+				`configuration.source.rid: must be at least 1 characters`,
+				`configuration: unknown property "unknown"`,
+				`configuration.when.timeslots[0].on_no_motion.recall_single[0].action: must be one of "all_off", "previous_state"`,
+				`configuration.where[0].group: missing required property "rtype"`,
+			},
+		},
+		{
+			name:          "missing required properties",
+			configuration: `{"when": {"timeslots": []}}`,
+			want: []string{
+				`configuration: missing required property "source"`,
+				`configuration: missing required property "where"`,
+				`configuration.when.timeslots: must have at least 1 items`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configuration any
+			if err := json.Unmarshal([]byte(tt.configuration), &configuration); err != nil {
+				t.Fatalf("invalid test configuration: %v", err)
+			}
+			got, err := ValidateConfiguration(json.RawMessage(motionSensorSchema), configuration)
+			if err != nil {
+				t.Fatalf("ValidateConfiguration() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateConfiguration() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfiguration_InvalidSchema(t *testing.T) {
+	_, err := ValidateConfiguration(json.RawMessage(`{"properties": {"a": {"$ref": "#/definitions/missing"}}}`), map[string]any{"a": 1})
+	if err == nil {
+		t.Error("ValidateConfiguration() expected an error for an unresolvable reference")
+	}
+}
+
+func TestValidateConfiguration_CircularReference(t *testing.T) {
+	schemas := []string{
+		`{"$ref": "#"}`,
+		`{"$ref": "#/definitions/a", "definitions": {"a": {"allOf": [{"$ref": "#/definitions/a"}]}}}`,
+	}
+	for _, schema := range schemas {
+		_, err := ValidateConfiguration(json.RawMessage(schema), map[string]any{"a": 1})
+		if err == nil {
+			t.Errorf("ValidateConfiguration() expected an error for the circular schema %s", schema)
+		}
+	}
+
+	// A recursive schema is fine as long as every reference descends into a nested value.
+	tree := `{"type": "object", "properties": {"child": {"$ref": "#"}, "name": {"type": "string"}}}`
+	got, err := ValidateConfiguration(json.RawMessage(tree), map[string]any{"child": map[string]any{"child": map[string]any{"name": 1}}})
+	if err != nil {
+		t.Fatalf("ValidateConfiguration() error = %v", err)
+	}
+	want := []string{"configuration.child.child.name: must be of type string"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateConfiguration() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,283 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"terraform-provider-philips/internal/provider/device"
+)
+
+// goToSleepScriptName is the name of the behavior script that runs go to sleep automations.
+const goToSleepScriptName = "Go to sleep"
+
+const defaultGoToSleepFadeOutDuration = 1800
+
+var (
+	_ resource.Resource                = &GoToSleepAutomationResource{}
+	_ resource.ResourceWithConfigure   = &GoToSleepAutomationResource{}
+	_ resource.ResourceWithImportState = &GoToSleepAutomationResource{}
+	_ resource.ResourceWithModifyPlan  = &GoToSleepAutomationResource{}
+)
+
+func NewGoToSleepAutomationResource() resource.Resource {
+	return &GoToSleepAutomationResource{}
+}
+
+// GoToSleepAutomationResource manages a Go to sleep behavior instance, which gradually dims the lights from a time
+// of day.
+type GoToSleepAutomationResource struct {
+	client device.ClientWithLightIDCache
+}
+
+type GoToSleepAutomationResourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	Name     types.String  `tfsdk:"name"`
+	Enabled  types.Bool    `tfsdk:"enabled"`
+	Targets  []TargetModel `tfsdk:"targets"`
+	Weekdays types.Set     `tfsdk:"weekdays"`
+	Hour     types.Int32   `tfsdk:"hour"`
+	Minute   types.Int32   `tfsdk:"minute"`
+	// The fade out duration in seconds.
+	FadeOutDuration types.Int64  `tfsdk:"fade_out_duration"`
+	EndState        types.String `tfsdk:"end_state"`
+}
+
+type goToSleepConfiguration struct {
+	EndState        string           `json:"end_state,omitempty"`
+	FadeOutDuration instanceDuration `json:"fade_out_duration"`
+	When            instanceWhen     `json:"when"`
+	Where           []instanceWhere  `json:"where"`
+}
+
+func (g *GoToSleepAutomationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_go_to_sleep_automation"
+}
+
+func (g *GoToSleepAutomationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Philips Hue Go to sleep automation, which gradually dims the lights starting at the configured time.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the automation.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the automation is enabled. Defaults to `true`.",
+			},
+			"targets":  targetsAttribute("The rooms and zones to dim."),
+			"weekdays": weekdaysAttribute("The days of the week to go to sleep on, such as `monday`. When not set, the automation runs once at the next occurrence of the time."),
+			"hour": schema.Int32Attribute{
+				Required:    true,
+				Description: "The hour at which the lights start to dim.",
+				Validators:  []validator.Int32{int32validator.Between(0, 23)},
+			},
+			"minute": schema.Int32Attribute{
+				Required:    true,
+				Description: "The minute at which the lights start to dim.",
+				Validators:  []validator.Int32{int32validator.Between(0, 59)},
+			},
+			"fade_out_duration": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultGoToSleepFadeOutDuration),
+				Description: "How long the lights take to dim in seconds, up to an hour. Defaults to 1800.",
+				Validators:  []validator.Int64{int64validator.Between(60, 3600)},
+			},
+			"end_state": schema.StringAttribute{
+				Optional:    true,
+				Description: "The state of the lights once they are dimmed, `turn_off` or `keep_on`. The bridge turns them off when not set.",
+				Validators:  []validator.String{stringvalidator.OneOf("turn_off", "keep_on")},
+			},
+		},
+	}
+}
+
+func (g *GoToSleepAutomationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(device.ClientWithLightIDCache)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected device.ClientWithLightIDCache, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	g.client = client
+}
+
+func (g *GoToSleepAutomationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !PlanKnownExceptID(req.Plan) || g.client == nil {
+		return
+	}
+	var data GoToSleepAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration, diags := createGoToSleepConfiguration(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateScriptConfiguration(g.client, goToSleepScriptName, configuration)...)
+}
+
+func (g *GoToSleepAutomationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GoToSleepAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration, diags := createGoToSleepConfiguration(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := createInstance(ctx, g.client, goToSleepScriptName, data.Name.ValueString(), data.Enabled.ValueBool(), configuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating go to sleep automation",
+			"Could not create go to sleep automation: "+err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (g *GoToSleepAutomationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GoToSleepAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configuration goToSleepConfiguration
+	instance, err := readInstance(ctx, g.client, data.ID.ValueString(), &configuration)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading go to sleep automation",
+			"Could not read go to sleep automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	data, diags := setGoToSleepModelFromBody(ctx, instance.ID, instance.Metadata.Name, instance.Enabled, configuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (g *GoToSleepAutomationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GoToSleepAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration, diags := createGoToSleepConfiguration(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateInstance(ctx, g.client, data.ID.ValueString(), data.Name.ValueString(), data.Enabled.ValueBool(), configuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating go to sleep automation",
+			"Could not update go to sleep automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (g *GoToSleepAutomationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GoToSleepAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := g.client.BehaviorInstanceService().DeleteBehaviorInstance(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting go to sleep automation",
+			"Could not delete go to sleep automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (g *GoToSleepAutomationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func createGoToSleepConfiguration(ctx context.Context, data GoToSleepAutomationResourceModel) (goToSleepConfiguration, diag.Diagnostics) {
+	days, diags := createRecurrenceDays(ctx, data.Weekdays)
+	configuration := goToSleepConfiguration{
+		EndState:        data.EndState.ValueString(),
+		FadeOutDuration: instanceDuration{Seconds: int(data.FadeOutDuration.ValueInt64())},
+		When: instanceWhen{
+			RecurrenceDays: days,
+			TimePoint: instanceTimePoint{
+				Type: timePointTypeTime,
+				Time: instanceTime{Hour: int(data.Hour.ValueInt32()), Minute: int(data.Minute.ValueInt32())},
+			},
+		},
+		Where: createWhere(data.Targets),
+	}
+	return configuration, diags
+}
+
+func setGoToSleepModelFromBody(ctx context.Context, id string, name string, enabled bool, configuration goToSleepConfiguration) (GoToSleepAutomationResourceModel, diag.Diagnostics) {
+	weekdays, diags := setWeekdaysFromBody(ctx, configuration.When.RecurrenceDays)
+	data := GoToSleepAutomationResourceModel{
+		ID:              types.StringValue(id),
+		Name:            types.StringValue(name),
+		Enabled:         types.BoolValue(enabled),
+		Targets:         setTargetsFromBody(configuration.Where),
+		Weekdays:        weekdays,
+		Hour:            types.Int32Value(int32(configuration.When.TimePoint.Time.Hour)),
+		Minute:          types.Int32Value(int32(configuration.When.TimePoint.Time.Minute)),
+		FadeOutDuration: types.Int64Value(int64(configuration.FadeOutDuration.Seconds)),
+		EndState:        types.StringNull(),
+	}
+	if configuration.EndState != "" {
+		data.EndState = types.StringValue(configuration.EndState)
+	}
+	return data, diags
+}
@@ -0,0 +1,98 @@
+package behavior
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Go to sleep configurations as returned by the bridge.
+const (
+	weeknightGoToSleepJSON = `{
+		"end_state": "turn_off",
+		"fade_out_duration": {"seconds": 900},
+		"when": {
+			"recurrence_days": ["sunday", "monday", "tuesday", "wednesday", "thursday"],
+			"time_point": {"type": "time", "time": {"hour": 22, "minute": 30}}
+		},
+		"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}]
+	}`
+	defaultGoToSleepJSON = `{
+		"fade_out_duration": {"seconds": 1800},
+		"when": {"time_point": {"type": "time", "time": {"hour": 23, "minute": 0}}},
+		"where": [{"group": {"rid": "f6a7b8c9-0000-4000-8000-000000000005", "rtype": "zone"}}]
+	}`
+)
+
+func TestGoToSleepAutomationMapping(t *testing.T) {
+	ctx := context.Background()
+	weekdays, _ := types.SetValueFrom(ctx, types.StringType, []string{"sunday", "monday", "tuesday", "wednesday", "thursday"})
+	tests := []struct {
+		name  string
+		body  string
+		model GoToSleepAutomationResourceModel
+	}{
+		{
+			name: "weeknights turning off",
+			body: weeknightGoToSleepJSON,
+			model: GoToSleepAutomationResourceModel{
+				ID:              types.StringValue("go-to-sleep"),
+				Name:            types.StringValue("Bedtime"),
+				Enabled:         types.BoolValue(false),
+				Targets:         []TargetModel{{Id: types.StringValue("d4e5f6a7-0000-4000-8000-000000000003"), Type: types.StringValue("room")}},
+				Weekdays:        weekdays,
+				Hour:            types.Int32Value(22),
+				Minute:          types.Int32Value(30),
+				FadeOutDuration: types.Int64Value(900),
+				EndState:        types.StringValue("turn_off"),
+			},
+		},
+		{
+			name: "once without end state",
+			body: defaultGoToSleepJSON,
+			model: GoToSleepAutomationResourceModel{
+				ID:              types.StringValue("go-to-sleep"),
+				Name:            types.StringValue("Bedtime"),
+				Enabled:         types.BoolValue(false),
+				Targets:         []TargetModel{{Id: types.StringValue("f6a7b8c9-0000-4000-8000-000000000005"), Type: types.StringValue("zone")}},
+				Weekdays:        types.SetNull(types.StringType),
+				Hour:            types.Int32Value(23),
+				Minute:          types.Int32Value(0),
+				FadeOutDuration: types.Int64Value(1800),
+				EndState:        types.StringNull(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		var body goToSleepConfiguration
+		if err := json.Unmarshal([]byte(tt.body), &body); err != nil {
+			t.Fatalf("%s: could not unmarshal bridge response: %v", tt.name, err)
+		}
+
+		t.Run(tt.name+"/read", func(t *testing.T) {
+			got, diags := setGoToSleepModelFromBody(ctx, "go-to-sleep", "Bedtime", false, body)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.model) {
+				t.Errorf("setGoToSleepModelFromBody() = %+v, want %+v", got, tt.model)
+			}
+		})
+
+		t.Run(tt.name+"/write", func(t *testing.T) {
+			got, diags := createGoToSleepConfiguration(ctx, tt.model)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			// The bridge lists the recurrence days from monday to sunday.
+			want := body
+			want.When.RecurrenceDays, _ = createRecurrenceDays(ctx, tt.model.Weekdays)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("createGoToSleepConfiguration() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
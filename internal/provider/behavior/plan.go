@@ -0,0 +1,24 @@
+package behavior
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// PlanKnownExceptID reports whether every planned value is known, other than the ID that is only known once the
+// resource has been created. The configuration of a behavior instance can only be validated when it is known.
+func PlanKnownExceptID(plan tfsdk.Plan) bool {
+	known := true
+	_ = tftypes.Walk(plan.Raw, func(p *tftypes.AttributePath, value tftypes.Value) (bool, error) {
+		if value.IsKnown() {
+			return true, nil
+		}
+		steps := p.Steps()
+		if len(steps) == 1 && steps[0].Equal(tftypes.AttributeName("id")) {
+			return false, nil
+		}
+		known = false
+		return false, nil
+	})
+	return known
+}
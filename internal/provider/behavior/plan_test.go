@@ -0,0 +1,40 @@
+package behavior
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlanKnownExceptID(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, "name": tftypes.String}}
+	tests := []struct {
+		name string
+		id   tftypes.Value
+		val  tftypes.Value
+		want bool
+	}{
+		{name: "known", id: tftypes.NewValue(tftypes.String, "id"), val: tftypes.NewValue(tftypes.String, "name"), want: true},
+		{name: "unknown id", id: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), val: tftypes.NewValue(tftypes.String, "name"), want: true},
+		{name: "unknown attribute", id: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), val: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{
+				Schema: testSchema,
+				Raw:    tftypes.NewValue(objectType, map[string]tftypes.Value{"id": tt.id, "name": tt.val}),
+			}
+			if got := PlanKnownExceptID(plan); got != tt.want {
+				t.Errorf("PlanKnownExceptID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"terraform-provider-philips/internal/provider/device"
+)
+
+// timerScriptName is the name of the behavior script that runs timers.
+const timerScriptName = "Timers"
+
+const (
+	timerEndStateTurnOff     = "turn_off"
+	timerEndStateRecallScene = "recall_scene"
+	// The timer duration in seconds is limited to a day.
+	minTimerDuration = 1
+	maxTimerDuration = 86400
+)
+
+var (
+	_ resource.Resource                   = &TimerAutomationResource{}
+	_ resource.ResourceWithConfigure      = &TimerAutomationResource{}
+	_ resource.ResourceWithImportState    = &TimerAutomationResource{}
+	_ resource.ResourceWithModifyPlan     = &TimerAutomationResource{}
+	_ resource.ResourceWithValidateConfig = &TimerAutomationResource{}
+)
+
+func NewTimerAutomationResource() resource.Resource {
+	return &TimerAutomationResource{}
+}
+
+// TimerAutomationResource manages a Timers behavior instance, which turns the lights off or recalls a scene once
+// its duration has passed after it is enabled.
+type TimerAutomationResource struct {
+	client device.ClientWithLightIDCache
+}
+
+type TimerAutomationResourceModel struct {
+	ID      types.String  `tfsdk:"id"`
+	Name    types.String  `tfsdk:"name"`
+	Enabled types.Bool    `tfsdk:"enabled"`
+	Targets []TargetModel `tfsdk:"targets"`
+	// The duration in seconds.
+	Duration types.Int64  `tfsdk:"duration"`
+	EndState types.String `tfsdk:"end_state"`
+	SceneID  types.String `tfsdk:"scene_id"`
+}
+
+type timerConfiguration struct {
+	Duration instanceDuration  `json:"duration"`
+	EndState string            `json:"end_state"`
+	Recall   *common.Reference `json:"recall,omitempty"`
+	Where    []instanceWhere   `json:"where"`
+}
+
+func (t *TimerAutomationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_timer_automation"
+}
+
+func (t *TimerAutomationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Philips Hue timer, which turns the lights off or recalls a scene once its duration has passed. The timer starts when it is enabled.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the automation.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the timer is enabled and running. Defaults to `true`.",
+			},
+			"targets": targetsAttribute("The rooms and zones the timer applies to."),
+			"duration": schema.Int64Attribute{
+				Required:    true,
+				Description: "The duration of the timer in seconds, up to a day.",
+				Validators:  []validator.Int64{int64validator.Between(minTimerDuration, maxTimerDuration)},
+			},
+			"end_state": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(timerEndStateTurnOff),
+				Description: "What happens when the timer ends: `turn_off` turns the lights off, `recall_scene` recalls `scene_id`. Defaults to `turn_off`.",
+				Validators:  []validator.String{stringvalidator.OneOf(timerEndStateTurnOff, timerEndStateRecallScene)},
+			},
+			"scene_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the scene to recall when the timer ends. Required when `end_state` is `recall_scene`.",
+			},
+		},
+	}
+}
+
+func (t *TimerAutomationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(device.ClientWithLightIDCache)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected device.ClientWithLightIDCache, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	t.client = client
+}
+
+func (t *TimerAutomationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TimerAutomationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateTimerEndState(data)...)
+}
+
+func (t *TimerAutomationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !PlanKnownExceptID(req.Plan) || t.client == nil {
+		return
+	}
+	var data TimerAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration := createTimerConfiguration(data)
+	resp.Diagnostics.Append(validateScriptConfiguration(t.client, timerScriptName, configuration)...)
+}
+
+func (t *TimerAutomationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TimerAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration := createTimerConfiguration(data)
+
+	id, err := createInstance(ctx, t.client, timerScriptName, data.Name.ValueString(), data.Enabled.ValueBool(), configuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating timer automation",
+			"Could not create timer automation: "+err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *TimerAutomationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TimerAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configuration timerConfiguration
+	instance, err := readInstance(ctx, t.client, data.ID.ValueString(), &configuration)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading timer automation",
+			"Could not read timer automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	data = setTimerModelFromBody(instance.ID, instance.Metadata.Name, instance.Enabled, configuration)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *TimerAutomationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TimerAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration := createTimerConfiguration(data)
+
+	err := updateInstance(ctx, t.client, data.ID.ValueString(), data.Name.ValueString(), data.Enabled.ValueBool(), configuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating timer automation",
+			"Could not update timer automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (t *TimerAutomationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TimerAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := t.client.BehaviorInstanceService().DeleteBehaviorInstance(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting timer automation",
+			"Could not delete timer automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (t *TimerAutomationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// validateTimerEndState checks that a scene is configured exactly when the timer ends by recalling it.
+func validateTimerEndState(data TimerAutomationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.EndState.IsUnknown() || data.SceneID.IsUnknown() {
+		return diags
+	}
+	recallsScene := data.EndState.ValueString() == timerEndStateRecallScene
+	if recallsScene && data.SceneID.IsNull() {
+		diags.AddAttributeError(path.Root("scene_id"), "Missing Timer Scene",
+			"A scene_id must be set when end_state is "+timerEndStateRecallScene+".")
+	}
+	if !recallsScene && !data.SceneID.IsNull() {
+		diags.AddAttributeError(path.Root("end_state"), "Timer Scene Not Recalled",
+			"The end_state must be "+timerEndStateRecallScene+" when a scene_id is set.")
+	}
+	return diags
+}
+
+func createTimerConfiguration(data TimerAutomationResourceModel) timerConfiguration {
+	configuration := timerConfiguration{
+		Duration: instanceDuration{Seconds: int(data.Duration.ValueInt64())},
+		EndState: data.EndState.ValueString(),
+		Where:    createWhere(data.Targets),
+	}
+	if !data.SceneID.IsNull() {
+		configuration.Recall = &common.Reference{RID: data.SceneID.ValueString(), RType: "scene"}
+	}
+	return configuration
+}
+
+func setTimerModelFromBody(id string, name string, enabled bool, configuration timerConfiguration) TimerAutomationResourceModel {
+	data := TimerAutomationResourceModel{
+		ID:       types.StringValue(id),
+		Name:     types.StringValue(name),
+		Enabled:  types.BoolValue(enabled),
+		Targets:  setTargetsFromBody(configuration.Where),
+		Duration: types.Int64Value(int64(configuration.Duration.Seconds)),
+		EndState: types.StringValue(configuration.EndState),
+		SceneID:  types.StringNull(),
+	}
+	if configuration.Recall != nil {
+		data.SceneID = types.StringValue(configuration.Recall.RID)
+	}
+	return data
+}
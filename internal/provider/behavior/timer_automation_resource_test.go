@@ -0,0 +1,108 @@
+package behavior
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Timer configurations as returned by the bridge.
+const (
+	bedtimeTimerJSON = `{
+		"duration": {"seconds": 1200},
+		"end_state": "turn_off",
+		"where": [
+			{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}},
+			{"group": {"rid": "e5f6a7b8-0000-4000-8000-000000000004", "rtype": "room"}}
+		]
+	}`
+	nightlightTimerJSON = `{
+		"duration": {"seconds": 600},
+		"end_state": "recall_scene",
+		"recall": {"rid": "c1d2e3f4-0000-4000-8000-000000000001", "rtype": "scene"},
+		"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}]
+	}`
+)
+
+func TestTimerAutomationMapping(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		model TimerAutomationResourceModel
+	}{
+		{
+			name: "turn off",
+			body: bedtimeTimerJSON,
+			model: TimerAutomationResourceModel{
+				ID:      types.StringValue("timer"),
+				Name:    types.StringValue("Bedtime"),
+				Enabled: types.BoolValue(true),
+				Targets: []TargetModel{
+					{Id: types.StringValue("d4e5f6a7-0000-4000-8000-000000000003"), Type: types.StringValue("room")},
+					{Id: types.StringValue("e5f6a7b8-0000-4000-8000-000000000004"), Type: types.StringValue("room")},
+				},
+				Duration: types.Int64Value(1200),
+				EndState: types.StringValue("turn_off"),
+				SceneID:  types.StringNull(),
+			},
+		},
+		{
+			name: "recall scene",
+			body: nightlightTimerJSON,
+			model: TimerAutomationResourceModel{
+				ID:       types.StringValue("timer"),
+				Name:     types.StringValue("Bedtime"),
+				Enabled:  types.BoolValue(true),
+				Targets:  []TargetModel{{Id: types.StringValue("d4e5f6a7-0000-4000-8000-000000000003"), Type: types.StringValue("room")}},
+				Duration: types.Int64Value(600),
+				EndState: types.StringValue("recall_scene"),
+				SceneID:  types.StringValue("c1d2e3f4-0000-4000-8000-000000000001"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		var body timerConfiguration
+		if err := json.Unmarshal([]byte(tt.body), &body); err != nil {
+			t.Fatalf("%s: could not unmarshal bridge response: %v", tt.name, err)
+		}
+
+		t.Run(tt.name+"/read", func(t *testing.T) {
+			if got := setTimerModelFromBody("timer", "Bedtime", true, body); !reflect.DeepEqual(got, tt.model) {
+				t.Errorf("setTimerModelFromBody() = %+v, want %+v", got, tt.model)
+			}
+		})
+
+		t.Run(tt.name+"/write", func(t *testing.T) {
+			if got := createTimerConfiguration(tt.model); !reflect.DeepEqual(got, body) {
+				t.Errorf("createTimerConfiguration() = %+v, want %+v", got, body)
+			}
+		})
+	}
+}
+
+func TestValidateTimerEndState(t *testing.T) {
+	tests := []struct {
+		name     string
+		endState types.String
+		sceneID  types.String
+		wantErr  bool
+	}{
+		{name: "turn off", endState: types.StringValue("turn_off"), sceneID: types.StringNull()},
+		{name: "default end state", endState: types.StringNull(), sceneID: types.StringNull()},
+		{name: "recall scene", endState: types.StringValue("recall_scene"), sceneID: types.StringValue("scene")},
+		{name: "recall without scene", endState: types.StringValue("recall_scene"), sceneID: types.StringNull(), wantErr: true},
+		{name: "scene when turning off", endState: types.StringValue("turn_off"), sceneID: types.StringValue("scene"), wantErr: true},
+		{name: "scene with default end state", endState: types.StringNull(), sceneID: types.StringValue("scene"), wantErr: true},
+		{name: "unknown scene", endState: types.StringValue("recall_scene"), sceneID: types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateTimerEndState(TimerAutomationResourceModel{EndState: tt.endState, SceneID: tt.sceneID})
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validateTimerEndState() errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package behavior
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/behavior_instance"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"slices"
+	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+// The typed automations below share the JSON shapes of the Hue behavior scripts they configure.
+
+type instanceWhere struct {
+	Group common.Reference `json:"group"`
+}
+
+type instanceDuration struct {
+	Seconds int `json:"seconds"`
+}
+
+type instanceTime struct {
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+// timePointTypeTime is a time point at a fixed time of day.
+const timePointTypeTime = "time"
+
+type instanceTimePoint struct {
+	Type string       `json:"type"`
+	Time instanceTime `json:"time"`
+}
+
+type instanceWhen struct {
+	RecurrenceDays []string          `json:"recurrence_days,omitempty"`
+	TimePoint      instanceTimePoint `json:"time_point"`
+}
+
+// TargetModel is a room or zone targeted by an automation.
+type TargetModel struct {
+	Id   types.String `tfsdk:"id"`
+	Type types.String `tfsdk:"type"`
+}
+
+func targetsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Required:    true,
+		Description: description,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Required:    true,
+					Description: "The ID of the target.",
+				},
+				"type": schema.StringAttribute{
+					Required:    true,
+					Description: "The type of the target, `room` or `zone`.",
+					Validators:  []validator.String{stringvalidator.OneOf("room", "zone")},
+				},
+			},
+		},
+	}
+}
+
+func weekdaysAttribute(description string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: description,
+		Validators: []validator.Set{
+			setvalidator.SizeAtLeast(1),
+			setvalidator.ValueStringsAre(stringvalidator.OneOf(huetypes.Weekdays...)),
+		},
+	}
+}
+
+func createWhere(targets []TargetModel) []instanceWhere {
+	where := make([]instanceWhere, len(targets))
+	for i, target := range targets {
+		where[i] = instanceWhere{Group: common.Reference{RID: target.Id.ValueString(), RType: target.Type.ValueString()}}
+	}
+	return where
+}
+
+func setTargetsFromBody(where []instanceWhere) []TargetModel {
+	targets := make([]TargetModel, len(where))
+	for i, w := range where {
+		targets[i] = TargetModel{Id: types.StringValue(w.Group.RID), Type: types.StringValue(w.Group.RType)}
+	}
+	return targets
+}
+
+// createRecurrenceDays returns the configured weekdays sorted from monday to sunday, or nil when the automation
+// only runs once.
+func createRecurrenceDays(ctx context.Context, days types.Set) ([]string, diag.Diagnostics) {
+	if days.IsNull() || days.IsUnknown() {
+		return nil, nil
+	}
+	var result []string
+	diags := days.ElementsAs(ctx, &result, false)
+	slices.SortFunc(result, func(a, b string) int {
+		return slices.Index(huetypes.Weekdays, a) - slices.Index(huetypes.Weekdays, b)
+	})
+	return result, diags
+}
+
+func setWeekdaysFromBody(ctx context.Context, days []string) (types.Set, diag.Diagnostics) {
+	if len(days) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, days)
+}
+
+// createInstance creates a behavior instance of the named script with a typed configuration and returns its ID.
+func createInstance(ctx context.Context, c device.ClientWithLightIDCache, scriptName string, name string, enabled bool, configuration any) (string, error) {
+	scriptID, err := c.GetBehaviorScriptIDForMetadataName(scriptName)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(configuration)
+	if err != nil {
+		return "", err
+	}
+	response, err := c.BehaviorInstanceService().CreateRawBehaviorInstance(ctx, behavior_instance.RawCreateRequest{
+		ScriptID:      scriptID,
+		Configuration: encoded,
+		Enabled:       enabled,
+		Metadata:      &behavior_instance.Metadata{Name: name},
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.RID, nil
+}
+
+// readInstance reads a behavior instance and decodes its configuration into configuration.
+func readInstance(ctx context.Context, c device.ClientWithLightIDCache, id string, configuration any) (*behavior_instance.RawData, error) {
+	instance, err := c.BehaviorInstanceService().GetRawBehaviorInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(instance.Configuration, configuration); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// updateInstance replaces the name, enabled state and configuration of a behavior instance.
+func updateInstance(ctx context.Context, c device.ClientWithLightIDCache, id string, name string, enabled bool, configuration any) error {
+	encoded, err := json.Marshal(configuration)
+	if err != nil {
+		return err
+	}
+	_, err = c.BehaviorInstanceService().UpdateRawBehaviorInstance(ctx, id, behavior_instance.RawUpdateRequest{
+		Configuration: encoded,
+		Enabled:       &enabled,
+		Metadata:      &behavior_instance.Metadata{Name: name},
+	})
+	return err
+}
+
+// validateScriptConfiguration validates a typed configuration against the configuration schema of the named script.
+func validateScriptConfiguration(c device.ClientWithLightIDCache, scriptName string, configuration any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	script, err := c.GetBehaviorScriptForMetadataName(scriptName)
+	if err != nil {
+		diags.AddError(
+			"Error reading behavior script",
+			"Could not find the "+scriptName+" behavior script: "+err.Error())
+		return diags
+	}
+	if len(script.ConfigurationSchema) == 0 {
+		return diags
+	}
+	violations, err := ValidateConfiguration(script.ConfigurationSchema, configuration)
+	if err != nil {
+		// An unsupported schema must not prevent the plan, the bridge still validates the configuration.
+		diags.AddWarning("Automation Not Validated",
+			"Could not validate the configuration against the "+scriptName+" behavior script: "+err.Error())
+		return diags
+	}
+	for _, violation := range violations {
+		diags.AddError("Invalid Automation Configuration",
+			"The "+scriptName+" behavior script rejects the configuration: "+violation)
+	}
+	return diags
+}
@@ -0,0 +1,320 @@
+package behavior
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+// wakeUpScriptName is the name of the behavior script that runs wake up automations.
+const wakeUpScriptName = "Wake up"
+
+const (
+	wakeUpStyleSunrise          = "sunrise"
+	defaultWakeUpFadeInDuration = 1800
+	defaultWakeUpEndBrightness  = 100
+)
+
+var (
+	_ resource.Resource                = &WakeUpAutomationResource{}
+	_ resource.ResourceWithConfigure   = &WakeUpAutomationResource{}
+	_ resource.ResourceWithImportState = &WakeUpAutomationResource{}
+	_ resource.ResourceWithModifyPlan  = &WakeUpAutomationResource{}
+)
+
+func NewWakeUpAutomationResource() resource.Resource {
+	return &WakeUpAutomationResource{}
+}
+
+// WakeUpAutomationResource manages a Wake up behavior instance, which gradually brightens the lights before a
+// time of day.
+type WakeUpAutomationResource struct {
+	client device.ClientWithLightIDCache
+}
+
+type WakeUpAutomationResourceModel struct {
+	ID       types.String  `tfsdk:"id"`
+	Name     types.String  `tfsdk:"name"`
+	Enabled  types.Bool    `tfsdk:"enabled"`
+	Targets  []TargetModel `tfsdk:"targets"`
+	Weekdays types.Set     `tfsdk:"weekdays"`
+	Hour     types.Int32   `tfsdk:"hour"`
+	Minute   types.Int32   `tfsdk:"minute"`
+	// The fade in and auto off durations in seconds.
+	FadeInDuration types.Int64         `tfsdk:"fade_in_duration"`
+	EndBrightness  huetypes.Brightness `tfsdk:"end_brightness"`
+	Style          types.String        `tfsdk:"style"`
+	AutoOffDelay   types.Int64         `tfsdk:"auto_off_delay"`
+}
+
+type wakeUpConfiguration struct {
+	EndBrightness      float64           `json:"end_brightness"`
+	FadeInDuration     instanceDuration  `json:"fade_in_duration"`
+	TurnLightsOffAfter *instanceDuration `json:"turn_lights_off_after,omitempty"`
+	Style              string            `json:"style,omitempty"`
+	When               instanceWhen      `json:"when"`
+	Where              []instanceWhere   `json:"where"`
+}
+
+func (w *WakeUpAutomationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wake_up_automation"
+}
+
+func (w *WakeUpAutomationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Philips Hue Wake up automation, which gradually brightens the lights so that they reach their end brightness at the configured time.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the automation.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the automation is enabled. Defaults to `true`.",
+			},
+			"targets":  targetsAttribute("The rooms and zones to wake up."),
+			"weekdays": weekdaysAttribute("The days of the week to wake up on, such as `monday`. When not set, the automation runs once at the next occurrence of the time."),
+			"hour": schema.Int32Attribute{
+				Required:    true,
+				Description: "The hour at which the lights reach their end brightness.",
+				Validators:  []validator.Int32{int32validator.Between(0, 23)},
+			},
+			"minute": schema.Int32Attribute{
+				Required:    true,
+				Description: "The minute at which the lights reach their end brightness.",
+				Validators:  []validator.Int32{int32validator.Between(0, 59)},
+			},
+			"fade_in_duration": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultWakeUpFadeInDuration),
+				Description: "How long the lights take to reach their end brightness in seconds, up to an hour. Defaults to 1800.",
+				Validators:  []validator.Int64{int64validator.Between(60, 3600)},
+			},
+			"end_brightness": schema.Float64Attribute{
+				Optional:    true,
+				Computed:    true,
+				CustomType:  huetypes.BrightnessType{},
+				Default:     float64default.StaticFloat64(defaultWakeUpEndBrightness),
+				Description: "The brightness from 1 to 100 the lights reach at the configured time. Defaults to 100.",
+				Validators:  []validator.Float64{float64validator.Between(1, 100)},
+			},
+			"style": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(wakeUpStyleSunrise),
+				Description: "How the lights fade in: `sunrise` fades through the colors of a sunrise on color lights, `basic` only brightens them. Defaults to `sunrise`.",
+				Validators:  []validator.String{stringvalidator.OneOf(wakeUpStyleSunrise, "basic")},
+			},
+			"auto_off_delay": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The time in seconds after which the lights turn off again, up to six hours. The lights stay on when not set.",
+				Validators:  []validator.Int64{int64validator.Between(60, 21600)},
+			},
+		},
+	}
+}
+
+func (w *WakeUpAutomationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(device.ClientWithLightIDCache)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected device.ClientWithLightIDCache, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	w.client = client
+}
+
+func (w *WakeUpAutomationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !PlanKnownExceptID(req.Plan) || w.client == nil {
+		return
+	}
+	var data WakeUpAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration, diags := createWakeUpConfiguration(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateScriptConfiguration(w.client, wakeUpScriptName, configuration)...)
+}
+
+func (w *WakeUpAutomationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WakeUpAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration, diags := createWakeUpConfiguration(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := createInstance(ctx, w.client, wakeUpScriptName, data.Name.ValueString(), data.Enabled.ValueBool(), configuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating wake up automation",
+			"Could not create wake up automation: "+err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (w *WakeUpAutomationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WakeUpAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configuration wakeUpConfiguration
+	instance, err := readInstance(ctx, w.client, data.ID.ValueString(), &configuration)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading wake up automation",
+			"Could not read wake up automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	data, diags := setWakeUpModelFromBody(ctx, instance.ID, instance.Metadata.Name, instance.Enabled, configuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (w *WakeUpAutomationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WakeUpAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	configuration, diags := createWakeUpConfiguration(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateInstance(ctx, w.client, data.ID.ValueString(), data.Name.ValueString(), data.Enabled.ValueBool(), configuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating wake up automation",
+			"Could not update wake up automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (w *WakeUpAutomationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WakeUpAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := w.client.BehaviorInstanceService().DeleteBehaviorInstance(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting wake up automation",
+			"Could not delete wake up automation ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (w *WakeUpAutomationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func createWakeUpConfiguration(ctx context.Context, data WakeUpAutomationResourceModel) (wakeUpConfiguration, diag.Diagnostics) {
+	days, diags := createRecurrenceDays(ctx, data.Weekdays)
+	configuration := wakeUpConfiguration{
+		EndBrightness:  data.EndBrightness.ValueFloat64(),
+		FadeInDuration: instanceDuration{Seconds: int(data.FadeInDuration.ValueInt64())},
+		Style:          data.Style.ValueString(),
+		When: instanceWhen{
+			RecurrenceDays: days,
+			TimePoint: instanceTimePoint{
+				Type: timePointTypeTime,
+				Time: instanceTime{Hour: int(data.Hour.ValueInt32()), Minute: int(data.Minute.ValueInt32())},
+			},
+		},
+		Where: createWhere(data.Targets),
+	}
+	if !data.AutoOffDelay.IsNull() {
+		configuration.TurnLightsOffAfter = &instanceDuration{Seconds: int(data.AutoOffDelay.ValueInt64())}
+	}
+	return configuration, diags
+}
+
+func setWakeUpModelFromBody(ctx context.Context, id string, name string, enabled bool, configuration wakeUpConfiguration) (WakeUpAutomationResourceModel, diag.Diagnostics) {
+	weekdays, diags := setWeekdaysFromBody(ctx, configuration.When.RecurrenceDays)
+	data := WakeUpAutomationResourceModel{
+		ID:             types.StringValue(id),
+		Name:           types.StringValue(name),
+		Enabled:        types.BoolValue(enabled),
+		Targets:        setTargetsFromBody(configuration.Where),
+		Weekdays:       weekdays,
+		Hour:           types.Int32Value(int32(configuration.When.TimePoint.Time.Hour)),
+		Minute:         types.Int32Value(int32(configuration.When.TimePoint.Time.Minute)),
+		FadeInDuration: types.Int64Value(int64(configuration.FadeInDuration.Seconds)),
+		EndBrightness:  huetypes.NewBrightnessValue(configuration.EndBrightness),
+		Style:          types.StringValue(configuration.Style),
+		AutoOffDelay:   types.Int64Null(),
+	}
+	// Instances created before the style was introduced always fade in like a sunrise.
+	if configuration.Style == "" {
+		data.Style = types.StringValue(wakeUpStyleSunrise)
+	}
+	if configuration.TurnLightsOffAfter != nil {
+		data.AutoOffDelay = types.Int64Value(int64(configuration.TurnLightsOffAfter.Seconds))
+	}
+	return data, diags
+}
@@ -0,0 +1,122 @@
+package behavior
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+// Wake up configurations as returned by the bridge.
+const (
+	weekdayWakeUpJSON = `{
+		"end_brightness": 80,
+		"fade_in_duration": {"seconds": 1200},
+		"turn_lights_off_after": {"seconds": 3600},
+		"style": "basic",
+		"when": {
+			"recurrence_days": ["monday", "tuesday", "friday"],
+			"time_point": {"type": "time", "time": {"hour": 6, "minute": 45}}
+		},
+		"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}]
+	}`
+	onceWakeUpJSON = `{
+		"end_brightness": 100,
+		"fade_in_duration": {"seconds": 1800},
+		"style": "sunrise",
+		"when": {"time_point": {"type": "time", "time": {"hour": 9, "minute": 0}}},
+		"where": [
+			{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}},
+			{"group": {"rid": "f6a7b8c9-0000-4000-8000-000000000005", "rtype": "zone"}}
+		]
+	}`
+)
+
+func TestWakeUpAutomationMapping(t *testing.T) {
+	ctx := context.Background()
+	weekdays, _ := types.SetValueFrom(ctx, types.StringType, []string{"monday", "tuesday", "friday"})
+	tests := []struct {
+		name  string
+		body  string
+		model WakeUpAutomationResourceModel
+	}{
+		{
+			name: "weekdays with auto off",
+			body: weekdayWakeUpJSON,
+			model: WakeUpAutomationResourceModel{
+				ID:             types.StringValue("wake-up"),
+				Name:           types.StringValue("Weekdays"),
+				Enabled:        types.BoolValue(true),
+				Targets:        []TargetModel{{Id: types.StringValue("d4e5f6a7-0000-4000-8000-000000000003"), Type: types.StringValue("room")}},
+				Weekdays:       weekdays,
+				Hour:           types.Int32Value(6),
+				Minute:         types.Int32Value(45),
+				FadeInDuration: types.Int64Value(1200),
+				EndBrightness:  huetypes.NewBrightnessValue(80),
+				Style:          types.StringValue("basic"),
+				AutoOffDelay:   types.Int64Value(3600),
+			},
+		},
+		{
+			name: "once",
+			body: onceWakeUpJSON,
+			model: WakeUpAutomationResourceModel{
+				ID:      types.StringValue("wake-up"),
+				Name:    types.StringValue("Weekdays"),
+				Enabled: types.BoolValue(true),
+				Targets: []TargetModel{
+					{Id: types.StringValue("d4e5f6a7-0000-4000-8000-000000000003"), Type: types.StringValue("room")},
+					{Id: types.StringValue("f6a7b8c9-0000-4000-8000-000000000005"), Type: types.StringValue("zone")},
+				},
+				Weekdays:       types.SetNull(types.StringType),
+				Hour:           types.Int32Value(9),
+				Minute:         types.Int32Value(0),
+				FadeInDuration: types.Int64Value(1800),
+				EndBrightness:  huetypes.NewBrightnessValue(100),
+				Style:          types.StringValue("sunrise"),
+				AutoOffDelay:   types.Int64Null(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		var body wakeUpConfiguration
+		if err := json.Unmarshal([]byte(tt.body), &body); err != nil {
+			t.Fatalf("%s: could not unmarshal bridge response: %v", tt.name, err)
+		}
+
+		t.Run(tt.name+"/read", func(t *testing.T) {
+			got, diags := setWakeUpModelFromBody(ctx, "wake-up", "Weekdays", true, body)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.model) {
+				t.Errorf("setWakeUpModelFromBody() = %+v, want %+v", got, tt.model)
+			}
+		})
+
+		t.Run(tt.name+"/write", func(t *testing.T) {
+			got, diags := createWakeUpConfiguration(ctx, tt.model)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !reflect.DeepEqual(got, body) {
+				t.Errorf("createWakeUpConfiguration() = %+v, want %+v", got, body)
+			}
+		})
+	}
+}
+
+func TestCreateRecurrenceDays(t *testing.T) {
+	ctx := context.Background()
+	days, _ := types.SetValueFrom(ctx, types.StringType, []string{"sunday", "monday", "wednesday"})
+	got, diags := createRecurrenceDays(ctx, days)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if want := []string{"monday", "wednesday", "sunday"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("createRecurrenceDays() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"github.com/richseviora/huego/pkg/resources/color"
+	"github.com/richseviora/huego/pkg/resources/light"
+	"math"
+)
+
+// gamutTolerance allows for the bridge reporting gamut corners with four decimals.
+const gamutTolerance = 0.0005
+
+// gamutContains reports whether the xy color lies within the triangle of the gamut.
+func gamutContains(g *light.Gamut, p color.XYCoord) bool {
+	closest := clampToGamut(g, p)
+	return math.Hypot(p.X-closest.X, p.Y-closest.Y) <= gamutTolerance
+}
+
+// clampToGamut returns the xy color within the gamut that is closest to the given color.
+func clampToGamut(g *light.Gamut, p color.XYCoord) color.XYCoord {
+	d1 := cross(g.Red, g.Green, p)
+	d2 := cross(g.Green, g.Blue, p)
+	d3 := cross(g.Blue, g.Red, p)
+	hasNegative := d1 < 0 || d2 < 0 || d3 < 0
+	hasPositive := d1 > 0 || d2 > 0 || d3 > 0
+	if !(hasNegative && hasPositive) {
+		return p
+	}
+
+	result := closestOnSegment(g.Red, g.Green, p)
+	for _, candidate := range []color.XYCoord{
+		closestOnSegment(g.Green, g.Blue, p),
+		closestOnSegment(g.Blue, g.Red, p),
+	} {
+		if math.Hypot(p.X-candidate.X, p.Y-candidate.Y) < math.Hypot(p.X-result.X, p.Y-result.Y) {
+			result = candidate
+		}
+	}
+	return result
+}
+
+func cross(a, b, p color.XYCoord) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
+func closestOnSegment(a, b, p color.XYCoord) color.XYCoord {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return a
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / lengthSquared
+	t = math.Max(0, math.Min(1, t))
+	return color.XYCoord{X: a.X + t*dx, Y: a.Y + t*dy}
+}
@@ -3,14 +3,21 @@ package device
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/richseviora/huego/pkg/resources/behavior_instance"
 	"github.com/richseviora/huego/pkg/resources/behavior_script"
 	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/convenience_area_motion"
 	"github.com/richseviora/huego/pkg/resources/device"
+	"github.com/richseviora/huego/pkg/resources/geolocation"
+	"github.com/richseviora/huego/pkg/resources/grouped_motion"
 	"github.com/richseviora/huego/pkg/resources/light"
+	"github.com/richseviora/huego/pkg/resources/light_level"
 	"github.com/richseviora/huego/pkg/resources/motion"
 	"github.com/richseviora/huego/pkg/resources/room"
 	"github.com/richseviora/huego/pkg/resources/scene"
+	"github.com/richseviora/huego/pkg/resources/smart_scene"
+	"github.com/richseviora/huego/pkg/resources/temperature"
 	"github.com/richseviora/huego/pkg/resources/zigbee_connectivity"
 	"github.com/richseviora/huego/pkg/resources/zone"
 	"slices"
@@ -43,6 +50,10 @@ type ClientWithLightIDCache interface {
 	GetLightIDForMacAddress(macAddress string) (string, error)
 	GetMotionIDForMacAddress(macAddress string) (string, error)
 	GetBehaviorScriptIDForMetadataName(name string) (string, error)
+	GetBehaviorScriptForMetadataName(name string) (behavior_script.Data, error)
+	GetBehaviorScript(id string) (behavior_script.Data, error)
+	GetMotionSourceType(ctx context.Context, id string) (string, error)
+	GetDeviceMappingEntry(deviceID string) (DeviceMappingEntry, error)
 }
 
 func NewClientWithCache(client client.HueServiceClient) *ClientWithCache {
@@ -80,6 +91,10 @@ func (c *ClientWithCache) buildDeviceMap(ctx context.Context) (map[string]Device
 				entry.ZigbeeConnectivityID = service.Rid
 			case "motion":
 				entry.MotionID = service.Rid
+			case "light_level":
+				entry.LightLevelID = service.Rid
+			case "temperature":
+				entry.TemperatureID = service.Rid
 			}
 		}
 		deviceMap[d.ID] = entry
@@ -98,32 +113,66 @@ func (c *ClientWithCache) buildDeviceMap(ctx context.Context) (map[string]Device
 }
 
 func (c *ClientWithCache) GetBehaviorScriptIDForMetadataName(name string) (string, error) {
+	script, err := c.GetBehaviorScriptForMetadataName(name)
+	if err != nil {
+		return "", err
+	}
+	return script.ID, nil
+}
+
+// GetBehaviorScriptForMetadataName returns the behavior script with the given name, including its configuration schema.
+func (c *ClientWithCache) GetBehaviorScriptForMetadataName(name string) (behavior_script.Data, error) {
+	script, err := c.findBehaviorScript(func(script behavior_script.Data) bool {
+		return script.Metadata.Name == name
+	})
+	if err != nil {
+		return behavior_script.Data{}, err
+	}
+	if script.ID == "" {
+		return behavior_script.Data{}, errors.New("could not find behavior script with name: " + name)
+	}
+	return script, nil
+}
+
+// GetBehaviorScript returns the behavior script with the given ID, including its configuration schema.
+func (c *ClientWithCache) GetBehaviorScript(id string) (behavior_script.Data, error) {
+	script, err := c.findBehaviorScript(func(script behavior_script.Data) bool {
+		return script.ID == id
+	})
+	if err != nil {
+		return behavior_script.Data{}, err
+	}
+	if script.ID == "" {
+		return behavior_script.Data{}, errors.New("could not find behavior script with ID: " + id)
+	}
+	return script, nil
+}
+
+// findBehaviorScript returns the first cached behavior script that matches, loading the scripts from the bridge
+// when none does.
+func (c *ClientWithCache) findBehaviorScript(matches func(behavior_script.Data) bool) (behavior_script.Data, error) {
 	c.behaviorScriptCache.mutex.Lock()
 	defer c.behaviorScriptCache.mutex.Unlock()
 
-	if cached, ok := c.behaviorScriptCache.cache[name]; ok {
-		if script, ok := cached.(behavior_script.Data); ok {
-			return script.ID, nil
+	for _, cached := range c.behaviorScriptCache.cache {
+		if script, ok := cached.(behavior_script.Data); ok && matches(script) {
+			return script, nil
 		}
 	}
 
 	scripts, err := c.client.BehaviorScriptService().GetAllBehaviorScripts(context.Background())
 	if err != nil {
-		return "", err
+		return behavior_script.Data{}, err
 	}
 
 	var result behavior_script.Data
 	for _, script := range scripts.Data {
 		c.behaviorScriptCache.cache[script.Metadata.Name] = script
-		if script.Metadata.Name == name {
+		if result.ID == "" && matches(script) {
 			result = script
 		}
 	}
-	if result.ID != "" {
-		return result.ID, nil
-	}
-
-	return "", errors.New("could not find behavior script with name: " + name)
+	return result, nil
 }
 
 func (c *ClientWithCache) GetLightIDForMacAddress(macAddress string) (string, error) {
@@ -134,7 +183,7 @@ func (c *ClientWithCache) GetLightIDForMacAddress(macAddress string) (string, er
 		return "", err
 	}
 	for _, d := range c.deviceCache {
-		if d.MacAddress == macAddress {
+		if d.MacAddress == NormalizeMacAddress(macAddress) {
 			return d.LightID, nil
 		}
 	}
@@ -149,13 +198,71 @@ func (c *ClientWithCache) GetMotionIDForMacAddress(macAddress string) (string, e
 		return "", err
 	}
 	for _, d := range c.deviceCache {
-		if d.MacAddress == macAddress {
+		if d.MacAddress == NormalizeMacAddress(macAddress) {
 			return d.MotionID, nil
 		}
 	}
 	return "", errors.New("could not find Mac Address in cache: " + macAddress + "")
 }
 
+// GetDeviceMappingEntry returns the services of a device, such as the light level and temperature services of a motion sensor.
+func (c *ClientWithCache) GetDeviceMappingEntry(deviceID string) (DeviceMappingEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, _, _, err := c.buildCache()
+	if err != nil {
+		return DeviceMappingEntry{}, err
+	}
+	entry, ok := c.deviceCache[deviceID]
+	if !ok {
+		return DeviceMappingEntry{}, fmt.Errorf("could not find device in cache: %s: %w", deviceID, client.ErrNotFound)
+	}
+	return entry, nil
+}
+
+// GetMotionSourceType returns the resource type of a motion source, which is either the motion service of a
+// sensor, a grouped_motion or a convenience_area_motion. It returns an empty string when the ID is not a motion
+// source, including grouped sources on firmware that does not support them.
+func (c *ClientWithCache) GetMotionSourceType(ctx context.Context, id string) (string, error) {
+	c.mutex.Lock()
+	_, _, _, err := c.buildCache()
+	if err != nil {
+		c.mutex.Unlock()
+		return "", err
+	}
+	for _, d := range c.deviceCache {
+		if d.MotionID == id {
+			c.mutex.Unlock()
+			return "motion", nil
+		}
+	}
+	c.mutex.Unlock()
+
+	groupedMotions, err := c.client.GroupedMotionService().GetAll(ctx)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		return "", err
+	}
+	if groupedMotions != nil {
+		for _, g := range groupedMotions.Data {
+			if g.ID == id {
+				return "grouped_motion", nil
+			}
+		}
+	}
+	areaMotions, err := c.client.ConvenienceAreaMotionService().GetAll(ctx)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		return "", err
+	}
+	if areaMotions != nil {
+		for _, a := range areaMotions.Data {
+			if a.ID == id {
+				return "convenience_area_motion", nil
+			}
+		}
+	}
+	return "", nil
+}
+
 func (c *ClientWithCache) GetAllDevices() ([]DeviceMappingEntry, []zigbee_connectivity.Data, error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -209,6 +316,10 @@ func (c *ClientWithCache) SceneService() scene.SceneService {
 	return c.client.SceneService()
 }
 
+func (c *ClientWithCache) SmartSceneService() smart_scene.Service {
+	return c.client.SmartSceneService()
+}
+
 func (c *ClientWithCache) LightService() light.LightService {
 	return c.client.LightService()
 }
@@ -233,4 +344,24 @@ func (c *ClientWithCache) BehaviorScriptService() behavior_script.Service {
 	return c.client.BehaviorScriptService()
 }
 
+func (c *ClientWithCache) GeolocationService() geolocation.Service {
+	return c.client.GeolocationService()
+}
+
+func (c *ClientWithCache) LightLevelService() light_level.Service {
+	return c.client.LightLevelService()
+}
+
+func (c *ClientWithCache) TemperatureService() temperature.Service {
+	return c.client.TemperatureService()
+}
+
+func (c *ClientWithCache) GroupedMotionService() grouped_motion.Service {
+	return c.client.GroupedMotionService()
+}
+
+func (c *ClientWithCache) ConvenienceAreaMotionService() convenience_area_motion.Service {
+	return c.client.ConvenienceAreaMotionService()
+}
+
 //endregion
@@ -1,11 +1,15 @@
 package device
 
+import "strings"
+
 type DeviceMappingEntry struct {
 	Name                 string
 	DeviceID             string
 	LightID              string
 	ZigbeeConnectivityID string
 	MotionID             string
+	LightLevelID         string
+	TemperatureID        string
 	MacAddress           string
 }
 
@@ -16,3 +20,8 @@ func (d DeviceMappingEntry) IsLight() bool {
 func (d DeviceMappingEntry) IsMotion() bool {
 	return d.MotionID != ""
 }
+
+// NormalizeMacAddress returns a MAC address in the lowercase, colon separated format the bridge reports.
+func NormalizeMacAddress(macAddress string) string {
+	return strings.ToLower(strings.ReplaceAll(macAddress, "-", ":"))
+}
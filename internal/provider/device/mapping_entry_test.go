@@ -0,0 +1,19 @@
+package device
+
+import "testing"
+
+func TestNormalizeMacAddress(t *testing.T) {
+	tests := []struct {
+		macAddress string
+		want       string
+	}{
+		{macAddress: "00:17:88:01:0c:d7:89:f9", want: "00:17:88:01:0c:d7:89:f9"},
+		{macAddress: "00:17:88:01:0C:D7:89:F9", want: "00:17:88:01:0c:d7:89:f9"},
+		{macAddress: "00-17-88-01-0c-d7-89-f9", want: "00:17:88:01:0c:d7:89:f9"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeMacAddress(tt.macAddress); got != tt.want {
+			t.Errorf("NormalizeMacAddress(%q) = %q, want %q", tt.macAddress, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package huetypes
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math"
+)
+
+// brightnessTolerance is one step of the 254 dimming levels the bridge stores brightness as.
+const brightnessTolerance = 100.0 / 254
+
+var (
+	_ basetypes.Float64Typable                    = BrightnessType{}
+	_ basetypes.Float64ValuableWithSemanticEquals = Brightness{}
+)
+
+// BrightnessType is a brightness percentage that the bridge rounds to its own dimming levels.
+type BrightnessType struct {
+	basetypes.Float64Type
+}
+
+func (t BrightnessType) Equal(o attr.Type) bool {
+	other, ok := o.(BrightnessType)
+	if !ok {
+		return false
+	}
+	return t.Float64Type.Equal(other.Float64Type)
+}
+
+func (t BrightnessType) String() string {
+	return "huetypes.BrightnessType"
+}
+
+func (t BrightnessType) ValueFromFloat64(_ context.Context, in basetypes.Float64Value) (basetypes.Float64Valuable, diag.Diagnostics) {
+	return Brightness{Float64Value: in}, nil
+}
+
+func (t BrightnessType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := float64FromTerraform(ctx, t.Float64Type, in)
+	if err != nil {
+		return nil, err
+	}
+	return Brightness{Float64Value: value}, nil
+}
+
+func (t BrightnessType) ValueType(_ context.Context) attr.Value {
+	return Brightness{}
+}
+
+// Brightness is a brightness percentage from 0 to 100. Values within one dimming level of each
+// other are semantically equal.
+type Brightness struct {
+	basetypes.Float64Value
+}
+
+func NewBrightnessNull() Brightness {
+	return Brightness{Float64Value: basetypes.NewFloat64Null()}
+}
+
+func NewBrightnessValue(value float64) Brightness {
+	return Brightness{Float64Value: basetypes.NewFloat64Value(value)}
+}
+
+func (v Brightness) Equal(o attr.Value) bool {
+	other, ok := o.(Brightness)
+	if !ok {
+		return false
+	}
+	return v.Float64Value.Equal(other.Float64Value)
+}
+
+func (v Brightness) Type(_ context.Context) attr.Type {
+	return BrightnessType{}
+}
+
+func (v Brightness) Float64SemanticEquals(_ context.Context, newValuable basetypes.Float64Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(Brightness)
+	if !ok {
+		diags.Append(semanticEqualityError(v, newValuable))
+		return false, diags
+	}
+	return math.Abs(v.ValueFloat64()-newValue.ValueFloat64()) <= brightnessTolerance, diags
+}
+
+// float64FromTerraform converts a Terraform value with the underlying float64 type of a custom type.
+func float64FromTerraform(ctx context.Context, t basetypes.Float64Type, in tftypes.Value) (basetypes.Float64Value, error) {
+	attrValue, err := t.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return basetypes.Float64Value{}, err
+	}
+	value, ok := attrValue.(basetypes.Float64Value)
+	if !ok {
+		return basetypes.Float64Value{}, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return value, nil
+}
+
+func semanticEqualityError(expected attr.Value, got any) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Semantic Equality Check Error",
+		"An unexpected value type was received while performing semantic equality checks. "+
+			"Please report this to the provider developers.\n\n"+
+			fmt.Sprintf("Expected Value Type: %T\n", expected)+
+			fmt.Sprintf("Got Value Type: %T", got),
+	)
+}
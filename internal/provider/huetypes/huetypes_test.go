@@ -0,0 +1,113 @@
+package huetypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestBrightnessSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    float64
+		current  float64
+		expected bool
+	}{
+		{name: "identical", prior: 50, current: 50, expected: true},
+		{name: "rounded to a dimming level", prior: 50, current: 50.2, expected: true},
+		{name: "different dimming level", prior: 50, current: 51, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := NewBrightnessValue(tt.prior).Float64SemanticEquals(context.Background(), NewBrightnessValue(tt.current))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != tt.expected {
+				t.Errorf("Float64SemanticEquals(%v, %v) = %v, want %v", tt.prior, tt.current, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestXYSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    float64
+		current  float64
+		expected bool
+	}{
+		{name: "identical", prior: 0.3127, current: 0.3127, expected: true},
+		{name: "reported with fewer decimals", prior: 0.31271592, current: 0.3127, expected: true},
+		{name: "different color", prior: 0.3127, current: 0.32, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := NewXYValue(tt.prior).Float64SemanticEquals(context.Background(), NewXYValue(tt.current))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != tt.expected {
+				t.Errorf("Float64SemanticEquals(%v, %v) = %v, want %v", tt.prior, tt.current, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKelvinSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    int32
+		current  int32
+		expected bool
+	}{
+		{name: "identical", prior: 2700, current: 2700, expected: true},
+		{name: "same mirek", prior: 2700, current: 2702, expected: true},
+		{name: "different mirek", prior: 2700, current: 2800, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := NewKelvinValue(tt.prior).Int32SemanticEquals(context.Background(), NewKelvinValue(tt.current))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != tt.expected {
+				t.Errorf("Int32SemanticEquals(%v, %v) = %v, want %v", tt.prior, tt.current, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSemanticEqualsRejectsOtherTypes(t *testing.T) {
+	_, diags := NewBrightnessValue(50).Float64SemanticEquals(context.Background(), basetypes.NewFloat64Value(50))
+	if !diags.HasError() {
+		t.Error("expected an error comparing a brightness with a plain float64")
+	}
+}
+
+func TestJSONSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    string
+		current  string
+		expected bool
+	}{
+		{name: "identical", prior: `{"a":1}`, current: `{"a":1}`, expected: true},
+		{name: "reordered and reformatted", prior: `{"a": 1, "b": [true, null]}`, current: `{"b":[true,null],"a":1}`, expected: true},
+		{name: "different value", prior: `{"a":1}`, current: `{"a":2}`, expected: false},
+		{name: "different array order", prior: `[1,2]`, current: `[2,1]`, expected: false},
+		{name: "invalid", prior: `{"a":1}`, current: `{"a":`, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := NewJSONValue(tt.prior).StringSemanticEquals(context.Background(), NewJSONValue(tt.current))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got != tt.expected {
+				t.Errorf("StringSemanticEquals(%v, %v) = %v, want %v", tt.prior, tt.current, got, tt.expected)
+			}
+		})
+	}
+}
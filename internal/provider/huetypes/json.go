@@ -0,0 +1,102 @@
+package huetypes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"reflect"
+)
+
+var (
+	_ basetypes.StringTypable                    = JSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSON{}
+)
+
+// JSONType is a JSON document, such as the configuration of a behavior instance.
+type JSONType struct {
+	basetypes.StringType
+}
+
+func (t JSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONType) String() string {
+	return "huetypes.JSONType"
+}
+
+func (t JSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSON{StringValue: in}, nil
+}
+
+func (t JSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return JSON{StringValue: value}, nil
+}
+
+func (t JSONType) ValueType(_ context.Context) attr.Value {
+	return JSON{}
+}
+
+// JSON is a JSON document. Documents that only differ in whitespace or the order of object keys
+// are semantically equal.
+type JSON struct {
+	basetypes.StringValue
+}
+
+func NewJSONNull() JSON {
+	return JSON{StringValue: basetypes.NewStringNull()}
+}
+
+func NewJSONValue(value string) JSON {
+	return JSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v JSON) Equal(o attr.Value) bool {
+	other, ok := o.(JSON)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v JSON) Type(_ context.Context) attr.Type {
+	return JSONType{}
+}
+
+// Unmarshal decodes the document into target.
+func (v JSON) Unmarshal(target any) error {
+	return json.Unmarshal([]byte(v.ValueString()), target)
+}
+
+func (v JSON) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(JSON)
+	if !ok {
+		diags.Append(semanticEqualityError(v, newValuable))
+		return false, diags
+	}
+	var prior, current any
+	if err := v.Unmarshal(&prior); err != nil {
+		return false, diags
+	}
+	if err := newValue.Unmarshal(&current); err != nil {
+		return false, diags
+	}
+	return reflect.DeepEqual(prior, current), diags
+}
@@ -0,0 +1,99 @@
+package huetypes
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/richseviora/huego/pkg/resources/color"
+)
+
+var (
+	_ basetypes.Int32Typable                    = KelvinType{}
+	_ basetypes.Int32ValuableWithSemanticEquals = Kelvin{}
+)
+
+// KelvinType is a color temperature in kelvin that the bridge stores in mirek.
+type KelvinType struct {
+	basetypes.Int32Type
+}
+
+func (t KelvinType) Equal(o attr.Type) bool {
+	other, ok := o.(KelvinType)
+	if !ok {
+		return false
+	}
+	return t.Int32Type.Equal(other.Int32Type)
+}
+
+func (t KelvinType) String() string {
+	return "huetypes.KelvinType"
+}
+
+func (t KelvinType) ValueFromInt32(_ context.Context, in basetypes.Int32Value) (basetypes.Int32Valuable, diag.Diagnostics) {
+	return Kelvin{Int32Value: in}, nil
+}
+
+func (t KelvinType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.Int32Type.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := attrValue.(basetypes.Int32Value)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return Kelvin{Int32Value: value}, nil
+}
+
+func (t KelvinType) ValueType(_ context.Context) attr.Value {
+	return Kelvin{}
+}
+
+// Kelvin is a color temperature in kelvin. Temperatures that convert to the same mirek value
+// are semantically equal, so 2700 K reading back as 2703 K is not a change.
+type Kelvin struct {
+	basetypes.Int32Value
+}
+
+func NewKelvinNull() Kelvin {
+	return Kelvin{Int32Value: basetypes.NewInt32Null()}
+}
+
+func NewKelvinValue(value int32) Kelvin {
+	return Kelvin{Int32Value: basetypes.NewInt32Value(value)}
+}
+
+// NewKelvinFromMirek converts a mirek value read from the bridge into kelvin.
+func NewKelvinFromMirek(mirek int) Kelvin {
+	return NewKelvinValue(int32(color.MirekToKelvinRounded(int32(mirek))))
+}
+
+func (v Kelvin) Equal(o attr.Value) bool {
+	other, ok := o.(Kelvin)
+	if !ok {
+		return false
+	}
+	return v.Int32Value.Equal(other.Int32Value)
+}
+
+func (v Kelvin) Type(_ context.Context) attr.Type {
+	return KelvinType{}
+}
+
+// Mirek returns the mirek value the bridge stores for the color temperature.
+func (v Kelvin) Mirek() int {
+	return int(color.KelvinToMirekRounded(int32(v.ValueInt32())))
+}
+
+func (v Kelvin) Int32SemanticEquals(_ context.Context, newValuable basetypes.Int32Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(Kelvin)
+	if !ok {
+		diags.Append(semanticEqualityError(v, newValuable))
+		return false, diags
+	}
+	return v.Mirek() == newValue.Mirek(), diags
+}
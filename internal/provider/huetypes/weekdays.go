@@ -0,0 +1,4 @@
+package huetypes
+
+// Weekdays are the days of the week as the bridge names them, in the order the Hue app lists them.
+var Weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
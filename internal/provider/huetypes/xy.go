@@ -0,0 +1,87 @@
+package huetypes
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math"
+)
+
+// xyTolerance covers the bridge returning xy coordinates with four decimals.
+const xyTolerance = 0.0005
+
+var (
+	_ basetypes.Float64Typable                    = XYType{}
+	_ basetypes.Float64ValuableWithSemanticEquals = XY{}
+)
+
+// XYType is an x or y coordinate in the CIE color space.
+type XYType struct {
+	basetypes.Float64Type
+}
+
+func (t XYType) Equal(o attr.Type) bool {
+	other, ok := o.(XYType)
+	if !ok {
+		return false
+	}
+	return t.Float64Type.Equal(other.Float64Type)
+}
+
+func (t XYType) String() string {
+	return "huetypes.XYType"
+}
+
+func (t XYType) ValueFromFloat64(_ context.Context, in basetypes.Float64Value) (basetypes.Float64Valuable, diag.Diagnostics) {
+	return XY{Float64Value: in}, nil
+}
+
+func (t XYType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := float64FromTerraform(ctx, t.Float64Type, in)
+	if err != nil {
+		return nil, err
+	}
+	return XY{Float64Value: value}, nil
+}
+
+func (t XYType) ValueType(_ context.Context) attr.Value {
+	return XY{}
+}
+
+// XY is an x or y coordinate from 0 to 1. Coordinates that only differ in the precision the
+// bridge reports them with are semantically equal.
+type XY struct {
+	basetypes.Float64Value
+}
+
+func NewXYNull() XY {
+	return XY{Float64Value: basetypes.NewFloat64Null()}
+}
+
+func NewXYValue(value float64) XY {
+	return XY{Float64Value: basetypes.NewFloat64Value(value)}
+}
+
+func (v XY) Equal(o attr.Value) bool {
+	other, ok := o.(XY)
+	if !ok {
+		return false
+	}
+	return v.Float64Value.Equal(other.Float64Value)
+}
+
+func (v XY) Type(_ context.Context) attr.Type {
+	return XYType{}
+}
+
+func (v XY) Float64SemanticEquals(_ context.Context, newValuable basetypes.Float64Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(XY)
+	if !ok {
+		diags.Append(semanticEqualityError(v, newValuable))
+		return false, diags
+	}
+	return math.Abs(v.ValueFloat64()-newValue.ValueFloat64()) <= xyTolerance, diags
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	device2 "github.com/richseviora/huego/pkg/resources/device"
+	"github.com/richseviora/huego/pkg/resources/light"
+)
+
+// lightArchetypes are the archetypes accepted by the bridge for a light.
+var lightArchetypes = []string{
+	"unknown_archetype", "classic_bulb", "sultan_bulb", "flood_bulb", "spot_bulb", "candle_bulb", "luster_bulb",
+	"pendant_round", "pendant_long", "ceiling_round", "ceiling_square", "floor_shade", "floor_lantern",
+	"table_shade", "recessed_ceiling", "recessed_floor", "single_spot", "double_spot", "table_wash",
+	"wall_lantern", "wall_shade", "flexible_lamp", "ground_spot", "wall_spot", "plug", "hue_go",
+	"hue_lightstrip", "hue_iris", "hue_bloom", "bollard", "wall_washer", "hue_play", "vintage_bulb",
+	"vintage_candle_bulb", "ellipse_bulb", "triangle_bulb", "small_globe_bulb", "large_globe_bulb",
+	"edison_bulb", "christmas_tree", "string_light", "hue_centris", "hue_lightstrip_tv", "hue_lightstrip_pc",
+	"hue_tube", "hue_signe", "pendant_spot", "ceiling_horizontal", "ceiling_tube",
+}
+
+var xyAttributeTypes = map[string]attr.Type{
+	"x": types.Float64Type,
+	"y": types.Float64Type,
+}
+
+var colorGamutAttributeTypes = map[string]attr.Type{
+	"red":   types.ObjectType{AttrTypes: xyAttributeTypes},
+	"green": types.ObjectType{AttrTypes: xyAttributeTypes},
+	"blue":  types.ObjectType{AttrTypes: xyAttributeTypes},
+}
+
+// LightCapabilitiesModel holds the read-only product and capability data of a light.
+// It is embedded in LightResourceModel so the attributes stay at the top level.
+type LightCapabilitiesModel struct {
+	ModelID               types.String `tfsdk:"model_id"`
+	ProductName           types.String `tfsdk:"product_name"`
+	ManufacturerName      types.String `tfsdk:"manufacturer_name"`
+	SoftwareVersion       types.String `tfsdk:"software_version"`
+	ColorGamutType        types.String `tfsdk:"color_gamut_type"`
+	ColorGamut            types.Object `tfsdk:"color_gamut"`
+	MirekMinimum          types.Int32  `tfsdk:"mirek_minimum"`
+	MirekMaximum          types.Int32  `tfsdk:"mirek_maximum"`
+	GradientPointsCapable types.Int32  `tfsdk:"gradient_points_capable"`
+	Effects               types.List   `tfsdk:"effects"`
+}
+
+func lightCapabilitiesSchema() map[string]schema.Attribute {
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:      true,
+			Description:   description,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		}
+	}
+	computedInt32 := func(description string) schema.Int32Attribute {
+		return schema.Int32Attribute{
+			Computed:      true,
+			Description:   description,
+			PlanModifiers: []planmodifier.Int32{int32planmodifier.UseStateForUnknown()},
+		}
+	}
+	return map[string]schema.Attribute{
+		"model_id":          computedString("The model ID of the device the light belongs to."),
+		"product_name":      computedString("The product name of the device the light belongs to."),
+		"manufacturer_name": computedString("The manufacturer of the device the light belongs to."),
+		"software_version":  computedString("The software version of the device the light belongs to."),
+		"color_gamut_type":  computedString("The color gamut type of the light (`A`, `B`, `C` or `other`). Null if the light does not support color."),
+		"color_gamut": schema.ObjectAttribute{
+			Computed:       true,
+			Description:    "The red, green and blue xy corners of the color gamut of the light. Null if the light does not support color.",
+			AttributeTypes: colorGamutAttributeTypes,
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"mirek_minimum":           computedInt32("The minimum supported color temperature in mirek. Null if the light does not support color temperature."),
+		"mirek_maximum":           computedInt32("The maximum supported color temperature in mirek. Null if the light does not support color temperature."),
+		"gradient_points_capable": computedInt32("The number of gradient points the light supports. Null if the light does not support gradients."),
+		"effects": schema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The effects supported by the light.",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+func xyObjectValue(x, y float64) types.Object {
+	value, _ := types.ObjectValue(xyAttributeTypes, map[string]attr.Value{
+		"x": types.Float64Value(x),
+		"y": types.Float64Value(y),
+	})
+	return value
+}
+
+func createLightCapabilitiesModel(ctx context.Context, l *light.Light, owner *device2.Data) (LightCapabilitiesModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := LightCapabilitiesModel{
+		ModelID:               types.StringValue(owner.ProductData.ModelID),
+		ProductName:           types.StringValue(owner.ProductData.ProductName),
+		ManufacturerName:      types.StringValue(owner.ProductData.ManufacturerName),
+		SoftwareVersion:       types.StringValue(owner.ProductData.SoftwareVersion),
+		ColorGamutType:        types.StringNull(),
+		ColorGamut:            types.ObjectNull(colorGamutAttributeTypes),
+		MirekMinimum:          types.Int32Null(),
+		MirekMaximum:          types.Int32Null(),
+		GradientPointsCapable: types.Int32Null(),
+	}
+
+	if l.Color != nil {
+		result.ColorGamutType = types.StringValue(l.Color.GamutType)
+		if l.Color.Gamut != nil {
+			gamut, gamutDiags := types.ObjectValue(colorGamutAttributeTypes, map[string]attr.Value{
+				"red":   xyObjectValue(l.Color.Gamut.Red.X, l.Color.Gamut.Red.Y),
+				"green": xyObjectValue(l.Color.Gamut.Green.X, l.Color.Gamut.Green.Y),
+				"blue":  xyObjectValue(l.Color.Gamut.Blue.X, l.Color.Gamut.Blue.Y),
+			})
+			diags.Append(gamutDiags...)
+			result.ColorGamut = gamut
+		}
+	}
+	if l.ColorTemperature != nil && l.ColorTemperature.MirekSchema != nil {
+		result.MirekMinimum = types.Int32Value(int32(l.ColorTemperature.MirekSchema.MirekMinimum))
+		result.MirekMaximum = types.Int32Value(int32(l.ColorTemperature.MirekSchema.MirekMaximum))
+	}
+	if l.Gradient != nil {
+		result.GradientPointsCapable = types.Int32Value(int32(l.Gradient.PointsCapable))
+	}
+
+	effects := make([]string, 0)
+	if l.Effects != nil {
+		effects = append(effects, l.Effects.EffectValues...)
+	}
+	effectList, effectDiags := types.ListValueFrom(ctx, types.StringType, effects)
+	diags.Append(effectDiags...)
+	result.Effects = effectList
+
+	return result, diags
+}
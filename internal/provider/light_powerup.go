@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/color"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/light"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+const powerupPresetCustom = "custom"
+
+// LightPowerupModel describes how a light behaves when power is restored.
+// Only the custom preset uses the on, dimming and color settings, the other
+// presets are fully defined by the bridge.
+type LightPowerupModel struct {
+	Preset           types.String           `tfsdk:"preset"`
+	OnMode           types.String           `tfsdk:"on_mode"`
+	On               types.Bool             `tfsdk:"on"`
+	DimmingMode      types.String           `tfsdk:"dimming_mode"`
+	Brightness       huetypes.Brightness    `tfsdk:"brightness"`
+	ColorMode        types.String           `tfsdk:"color_mode"`
+	ColorTemperature huetypes.Kelvin        `tfsdk:"color_temperature"`
+	Color            *SceneActionColorModel `tfsdk:"color"`
+}
+
+func lightPowerupSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The behavior of the light when power is restored, for example after a power outage.",
+		Attributes: map[string]schema.Attribute{
+			"preset": schema.StringAttribute{
+				Required:    true,
+				Description: "The power-up preset. `safety`, `powerfail` and `last_on_state` are managed by the bridge, `custom` uses the remaining attributes.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("safety", "powerfail", "last_on_state", powerupPresetCustom),
+				},
+			},
+			"on_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the on state is restored: `on`, `toggle` or `previous`. Required for the custom preset.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("on", "toggle", "previous"),
+				},
+			},
+			"on": schema.BoolAttribute{
+				Optional:    true,
+				Description: "The on state to restore when `on_mode` is `on`.",
+			},
+			"dimming_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the brightness is restored: `dimming` or `previous`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("dimming", "previous"),
+				},
+			},
+			"brightness": schema.Float64Attribute{
+				Optional:    true,
+				CustomType:  huetypes.BrightnessType{},
+				Description: "The brightness to restore from 0 to 100 when `dimming_mode` is `dimming`.",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+				},
+			},
+			"color_mode": schema.StringAttribute{
+				Optional:    true,
+				Description: "How the color is restored: `color_temperature`, `color` or `previous`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("color_temperature", "color", "previous"),
+				},
+			},
+			"color_temperature": schema.Int32Attribute{
+				Optional:    true,
+				CustomType:  huetypes.KelvinType{},
+				Description: "The color temperature to restore from 2000 K to 6500 K when `color_mode` is `color_temperature`.",
+				Validators: []validator.Int32{
+					int32validator.Between(2000, 6500),
+				},
+			},
+			"color": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The xy color to restore when `color_mode` is `color`.",
+				Attributes: map[string]schema.Attribute{
+					"x": schema.Float64Attribute{
+						Required:    true,
+						CustomType:  huetypes.XYType{},
+						Description: "The x value of the color.",
+						Validators: []validator.Float64{
+							float64validator.Between(0, 1),
+						},
+					},
+					"y": schema.Float64Attribute{
+						Required:    true,
+						CustomType:  huetypes.XYType{},
+						Description: "The y value of the color.",
+						Validators: []validator.Float64{
+							float64validator.Between(0, 1),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateLightPowerup applies the CLIP v2 rules for combining the power-up settings.
+func validateLightPowerup(p *LightPowerupModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if p == nil || p.Preset.IsUnknown() {
+		return diags
+	}
+	root := path.Root("powerup")
+	isSet := func(v interface{ IsNull() bool }) bool {
+		return !v.IsNull()
+	}
+
+	if p.Preset.ValueString() != powerupPresetCustom {
+		if isSet(p.OnMode) || isSet(p.On) || isSet(p.DimmingMode) || isSet(p.Brightness) ||
+			isSet(p.ColorMode) || isSet(p.ColorTemperature) || p.Color != nil {
+			diags.AddAttributeError(root.AtName("preset"), "Invalid Power-up Configuration",
+				"The on, dimming and color settings can only be used with the \"custom\" preset. The \""+p.Preset.ValueString()+"\" preset is managed by the bridge.")
+		}
+		return diags
+	}
+
+	if p.OnMode.IsNull() {
+		diags.AddAttributeError(root.AtName("on_mode"), "Invalid Power-up Configuration",
+			"on_mode is required when the preset is \"custom\".")
+	} else if !p.OnMode.IsUnknown() {
+		if p.OnMode.ValueString() == "on" && p.On.IsNull() {
+			diags.AddAttributeError(root.AtName("on"), "Invalid Power-up Configuration",
+				"on is required when on_mode is \"on\".")
+		} else if p.OnMode.ValueString() != "on" && isSet(p.On) {
+			diags.AddAttributeError(root.AtName("on"), "Invalid Power-up Configuration",
+				"on can only be set when on_mode is \"on\".")
+		}
+	}
+
+	if !p.DimmingMode.IsUnknown() {
+		if p.DimmingMode.ValueString() == "dimming" && p.Brightness.IsNull() {
+			diags.AddAttributeError(root.AtName("brightness"), "Invalid Power-up Configuration",
+				"brightness is required when dimming_mode is \"dimming\".")
+		} else if p.DimmingMode.ValueString() != "dimming" && isSet(p.Brightness) {
+			diags.AddAttributeError(root.AtName("brightness"), "Invalid Power-up Configuration",
+				"brightness can only be set when dimming_mode is \"dimming\".")
+		}
+	}
+
+	if !p.ColorMode.IsUnknown() {
+		switch p.ColorMode.ValueString() {
+		case "color_temperature":
+			if p.ColorTemperature.IsNull() {
+				diags.AddAttributeError(root.AtName("color_temperature"), "Invalid Power-up Configuration",
+					"color_temperature is required when color_mode is \"color_temperature\".")
+			}
+			if p.Color != nil {
+				diags.AddAttributeError(root.AtName("color"), "Invalid Power-up Configuration",
+					"color can only be set when color_mode is \"color\".")
+			}
+		case "color":
+			if p.Color == nil {
+				diags.AddAttributeError(root.AtName("color"), "Invalid Power-up Configuration",
+					"color is required when color_mode is \"color\".")
+			}
+			if isSet(p.ColorTemperature) {
+				diags.AddAttributeError(root.AtName("color_temperature"), "Invalid Power-up Configuration",
+					"color_temperature can only be set when color_mode is \"color_temperature\".")
+			}
+		default:
+			if isSet(p.ColorTemperature) || p.Color != nil {
+				diags.AddAttributeError(root.AtName("color_mode"), "Invalid Power-up Configuration",
+					"color_temperature and color require color_mode to be set to \"color_temperature\" or \"color\".")
+			}
+		}
+	}
+	return diags
+}
+
+func createLightPowerupObj(p *LightPowerupModel) *light.Powerup {
+	if p == nil {
+		return nil
+	}
+	result := &light.Powerup{
+		Preset: p.Preset.ValueString(),
+	}
+	if result.Preset != powerupPresetCustom {
+		return result
+	}
+
+	result.On = &light.PowerupOn{Mode: p.OnMode.ValueString()}
+	if result.On.Mode == "on" {
+		result.On.On = &light.On{On: p.On.ValueBool()}
+	}
+	if !p.DimmingMode.IsNull() {
+		result.Dimming = &light.PowerupDimming{Mode: p.DimmingMode.ValueString()}
+		if result.Dimming.Mode == "dimming" {
+			result.Dimming.Dimming = &common.Dimming{Brightness: p.Brightness.ValueFloat64()}
+		}
+	}
+	if !p.ColorMode.IsNull() {
+		result.Color = &light.PowerupColor{Mode: p.ColorMode.ValueString()}
+		switch result.Color.Mode {
+		case "color_temperature":
+			result.Color.ColorTemperature = &light.ColorTemperature{
+				Mirek: p.ColorTemperature.Mirek(),
+			}
+		case "color":
+			result.Color.Color = &light.Color{
+				XY: color.XYCoord{
+					X: p.Color.X.ValueFloat64(),
+					Y: p.Color.Y.ValueFloat64(),
+				},
+			}
+		}
+	}
+	return result
+}
+
+func createLightPowerupModel(p *light.Powerup) *LightPowerupModel {
+	if p == nil {
+		return nil
+	}
+	result := &LightPowerupModel{
+		Preset: types.StringValue(p.Preset),
+	}
+	if p.Preset != powerupPresetCustom {
+		return result
+	}
+
+	if p.On != nil {
+		result.OnMode = types.StringValue(p.On.Mode)
+		if p.On.On != nil {
+			result.On = types.BoolValue(p.On.On.On)
+		}
+	}
+	if p.Dimming != nil {
+		result.DimmingMode = types.StringValue(p.Dimming.Mode)
+		if p.Dimming.Dimming != nil {
+			result.Brightness = huetypes.NewBrightnessValue(p.Dimming.Dimming.Brightness)
+		}
+	}
+	if p.Color != nil {
+		result.ColorMode = types.StringValue(p.Color.Mode)
+		if p.Color.ColorTemperature != nil {
+			result.ColorTemperature = huetypes.NewKelvinFromMirek(p.Color.ColorTemperature.Mirek)
+		}
+		if p.Color.Color != nil {
+			result.Color = &SceneActionColorModel{
+				X: huetypes.NewXYValue(p.Color.Color.XY.X),
+				Y: huetypes.NewXYValue(p.Color.Color.XY.Y),
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+func TestValidateLightPowerup(t *testing.T) {
+	tests := []struct {
+		name    string
+		powerup *LightPowerupModel
+		wantErr bool
+	}{
+		{
+			name:    "not configured",
+			powerup: nil,
+		},
+		{
+			name:    "bridge preset",
+			powerup: &LightPowerupModel{Preset: types.StringValue("safety")},
+		},
+		{
+			name: "bridge preset with custom settings",
+			powerup: &LightPowerupModel{
+				Preset: types.StringValue("powerfail"),
+				OnMode: types.StringValue("on"),
+				On:     types.BoolValue(true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom dim warm",
+			powerup: &LightPowerupModel{
+				Preset:           types.StringValue("custom"),
+				OnMode:           types.StringValue("on"),
+				On:               types.BoolValue(true),
+				DimmingMode:      types.StringValue("dimming"),
+				Brightness:       huetypes.NewBrightnessValue(10),
+				ColorMode:        types.StringValue("color_temperature"),
+				ColorTemperature: huetypes.NewKelvinValue(2200),
+			},
+		},
+		{
+			name:    "custom without on mode",
+			powerup: &LightPowerupModel{Preset: types.StringValue("custom")},
+			wantErr: true,
+		},
+		{
+			name: "custom on mode without on",
+			powerup: &LightPowerupModel{
+				Preset: types.StringValue("custom"),
+				OnMode: types.StringValue("on"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom dimming without brightness",
+			powerup: &LightPowerupModel{
+				Preset:      types.StringValue("custom"),
+				OnMode:      types.StringValue("previous"),
+				DimmingMode: types.StringValue("dimming"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom color mode with temperature",
+			powerup: &LightPowerupModel{
+				Preset:           types.StringValue("custom"),
+				OnMode:           types.StringValue("previous"),
+				ColorMode:        types.StringValue("color"),
+				ColorTemperature: huetypes.NewKelvinValue(2700),
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom color",
+			powerup: &LightPowerupModel{
+				Preset:    types.StringValue("custom"),
+				OnMode:    types.StringValue("toggle"),
+				ColorMode: types.StringValue("color"),
+				Color: &SceneActionColorModel{
+					X: huetypes.NewXYValue(0.5610),
+					Y: huetypes.NewXYValue(0.4042),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateLightPowerup(tt.powerup)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validateLightPowerup() errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
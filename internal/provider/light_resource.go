@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -13,6 +15,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/richseviora/huego/pkg/resources/client"
+	device2 "github.com/richseviora/huego/pkg/resources/device"
 	"github.com/richseviora/huego/pkg/resources/light"
 	"regexp"
 	"terraform-provider-philips/internal/provider/device"
@@ -21,20 +25,23 @@ import (
 var _ resource.Resource = &LightResource{}
 var _ resource.ResourceWithImportState = &LightResource{}
 var _ resource.ResourceWithConfigure = &LightResource{}
+var _ resource.ResourceWithValidateConfig = &LightResource{}
 
 type LightResource struct {
 	client device.ClientWithLightIDCache
 }
 
 type LightResourceModel struct {
-	Id       types.String `tfsdk:"id"`
-	Type     types.String `tfsdk:"type"`
-	Name     types.String `tfsdk:"name"`
-	Function types.String `tfsdk:"function"`
-	DeviceID types.String `tfsdk:"device_id"`
-	// Archetype types.String `tfsdk:"archetype"`
-	// TODO: Add power-on attributes
-
+	Id         types.String       `tfsdk:"id"`
+	Type       types.String       `tfsdk:"type"`
+	Name       types.String       `tfsdk:"name"`
+	Function   types.String       `tfsdk:"function"`
+	DeviceID   types.String       `tfsdk:"device_id"`
+	Powerup    *LightPowerupModel `tfsdk:"powerup"`
+	Archetype  types.String       `tfsdk:"archetype"`
+	MacAddress types.String       `tfsdk:"mac_address"`
+	OnDestroy  types.String       `tfsdk:"on_destroy"`
+	LightCapabilitiesModel
 }
 
 func NewLightResource() resource.Resource {
@@ -48,7 +55,7 @@ func (l *LightResource) Metadata(ctx context.Context, request resource.MetadataR
 func (l *LightResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		MarkdownDescription: "A representation of a Philips Hue light.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: lightAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The UUID of the Light device in the Hue Bridge. ",
@@ -87,8 +94,62 @@ func (l *LightResource) Schema(ctx context.Context, request resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-		},
+			"powerup": lightPowerupSchema(),
+			"archetype": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The archetype of the Light device in the Hue Bridge. Defaults to the archetype reported by the bridge.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(lightArchetypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mac_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "The MAC address of the light, for example `00:17:88:01:0c:e6:56:86`. When set, creating the resource adopts the existing light with this MAC address instead of requiring an import.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(macAddressRegex, "must be a MAC address"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, request planmodifier.StringRequest, response *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						// Adding or removing the MAC address of an imported light, or changing its format, must not replace it.
+						response.RequiresReplace = !request.StateValue.IsNull() && !request.ConfigValue.IsNull() && !request.ConfigValue.IsUnknown() &&
+							device.NormalizeMacAddress(request.StateValue.ValueString()) != device.NormalizeMacAddress(request.ConfigValue.ValueString())
+					}, "Changing the MAC address adopts a different light.", "Changing the MAC address adopts a different light."),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("forget"),
+				Description: "What happens to the light when the resource is destroyed. `forget` only removes it from the Terraform state, `reset` restores the factory name and archetype and the default function, and `unpair` deletes the device from the bridge.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("forget", "reset", "unpair"),
+				},
+			},
+		}),
+	}
+}
+
+var macAddressRegex = regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5,7}[0-9A-Fa-f]{2}$`)
+
+// lightAttributes merges the read-only capability attributes into the configurable light attributes.
+func lightAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	for name, attribute := range lightCapabilitiesSchema() {
+		attributes[name] = attribute
 	}
+	return attributes
+}
+
+func (l *LightResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data LightResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(validateLightPowerup(data.Powerup)...)
 }
 
 func (l *LightResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
@@ -107,8 +168,56 @@ func (l *LightResource) Configure(ctx context.Context, request resource.Configur
 }
 
 func (l *LightResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
-	response.Diagnostics.AddError("Not implemented", "Direct create is not supported for this resource. Please import the resource instead.")
-	return
+	var data LightResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if data.MacAddress.IsNull() {
+		response.Diagnostics.AddError("Not implemented", "Direct create is not supported for this resource without a mac_address. Please set mac_address or import the resource instead.")
+		return
+	}
+
+	lightID, err := l.client.GetLightIDForMacAddress(data.MacAddress.ValueString())
+	if err == nil && lightID == "" {
+		err = errors.New("device with MAC address " + data.MacAddress.ValueString() + " is not a light")
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Error adopting light",
+			"Could not find light with MAC address "+data.MacAddress.ValueString()+": "+err.Error())
+		return
+	}
+	data.Id = types.StringValue(lightID)
+	tflog.Info(ctx, "Adopting Light", map[string]interface{}{"id": lightID, "mac_address": data.MacAddress.ValueString()})
+
+	err = l.updateLight(ctx, data)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Error adopting light",
+			"Could not update light ID "+lightID+": "+err.Error())
+		return
+	}
+
+	light, err := l.client.LightService().GetLight(ctx, lightID)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Error adopting light",
+			"Could not read light ID "+lightID+": "+err.Error())
+		return
+	}
+	data.DeviceID = types.StringValue(light.Owner.RID)
+	if data.Archetype.IsUnknown() {
+		data.Archetype = types.StringValue(light.Metadata.Archetype)
+	}
+	capabilities, diags := l.readCapabilities(ctx, light)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.LightCapabilitiesModel = capabilities
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
 func (l *LightResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
@@ -117,8 +226,24 @@ func (l *LightResource) Read(ctx context.Context, request resource.ReadRequest,
 	if response.Diagnostics.HasError() {
 		return
 	}
+	if data.OnDestroy.IsNull() {
+		// Imported before on_destroy was set on import.
+		data.OnDestroy = types.StringValue("forget")
+	}
 	light, err := l.client.LightService().GetLight(ctx, data.Id.ValueString())
 	tflog.Info(ctx, "Returning Value", map[string]interface{}{"light": light, "err": err, "id": data.Id.ValueString()})
+	if errors.Is(err, client.ErrNotFound) {
+		newID := l.findRepairedLightID(data)
+		if newID == "" {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddWarning(
+			"Light re-paired",
+			"Light ID "+data.Id.ValueString()+" no longer exists, but MAC address "+data.MacAddress.ValueString()+" now belongs to light ID "+newID+". The resource now tracks the new light ID.")
+		data.Id = types.StringValue(newID)
+		light, err = l.client.LightService().GetLight(ctx, newID)
+	}
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Error reading light",
@@ -129,6 +254,11 @@ func (l *LightResource) Read(ctx context.Context, request resource.ReadRequest,
 	data.Function = types.StringValue(light.Metadata.Function)
 	data.Id = types.StringValue(light.ID)
 	data.DeviceID = types.StringValue(light.Owner.RID)
+	data.Archetype = types.StringValue(light.Metadata.Archetype)
+	// Power-up behavior is only tracked once it is managed by Terraform.
+	if data.Powerup != nil {
+		data.Powerup = createLightPowerupModel(light.Powerup)
+	}
 	if data.Id.ValueString() == "" {
 		response.Diagnostics.AddError(
 			"Error reading light",
@@ -136,9 +266,42 @@ func (l *LightResource) Read(ctx context.Context, request resource.ReadRequest,
 		return
 	}
 
+	capabilities, diags := l.readCapabilities(ctx, light)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	data.LightCapabilitiesModel = capabilities
+
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
+// findRepairedLightID returns the current light ID for the MAC address of the light,
+// or an empty string when the light cannot be found under a different ID.
+func (l *LightResource) findRepairedLightID(data LightResourceModel) string {
+	if data.MacAddress.IsNull() || data.MacAddress.IsUnknown() {
+		return ""
+	}
+	lightID, err := l.client.GetLightIDForMacAddress(data.MacAddress.ValueString())
+	if err != nil || lightID == data.Id.ValueString() {
+		return ""
+	}
+	return lightID
+}
+
+// readCapabilities reads the owner device of the light to build its capability attributes.
+func (l *LightResource) readCapabilities(ctx context.Context, light *light.Light) (LightCapabilitiesModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	owner, err := l.client.DeviceService().GetDevice(ctx, light.Owner.RID)
+	if err != nil {
+		diags.AddError(
+			"Error reading light",
+			"Could not read device ID "+light.Owner.RID+" for light ID "+light.ID+": "+err.Error())
+		return LightCapabilitiesModel{}, diags
+	}
+	return createLightCapabilitiesModel(ctx, light, owner)
+}
+
 func (l *LightResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
 	var data LightResourceModel
 
@@ -149,10 +312,26 @@ func (l *LightResource) Update(ctx context.Context, request resource.UpdateReque
 		return
 	}
 
-	update := struct {
-		Name     *string `json:"name"`
-		Function *string `json:"function"`
-	}{Name: nil, Function: nil}
+	err := l.updateLight(ctx, data)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Error updating light",
+			"Could not update light ID "+data.Id.ValueString()+": "+err.Error())
+		return
+	}
+	// Save updated data into Terraform state
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// lightMetadataUpdate is the metadata sent when a light is updated or reset. The archetype is only sent when it is set.
+type lightMetadataUpdate struct {
+	Name      *string `json:"name"`
+	Function  *string `json:"function"`
+	Archetype *string `json:"archetype,omitempty"`
+}
+
+func createLightMetadataUpdate(data LightResourceModel) *lightMetadataUpdate {
+	update := &lightMetadataUpdate{}
 	if data.Name.ValueString() != "" {
 		update.Name = data.Name.ValueStringPointer()
 	}
@@ -162,47 +341,92 @@ func (l *LightResource) Update(ctx context.Context, request resource.UpdateReque
 		s := "decorative"
 		update.Function = &s
 	}
+	if data.Archetype.ValueString() != "" {
+		update.Archetype = data.Archetype.ValueStringPointer()
+	}
+	return update
+}
+
+// resetLightMetadataUpdate restores the factory name, archetype and the default function of a light from the
+// product data of its device.
+func resetLightMetadataUpdate(product device2.ProductData) *lightMetadataUpdate {
+	name := product.ProductName
+	function := "decorative"
+	update := &lightMetadataUpdate{Name: &name, Function: &function}
+	if product.ProductArchetype != "" {
+		archetype := product.ProductArchetype
+		update.Archetype = &archetype
+	}
+	return update
+}
 
+func (l *LightResource) updateLight(ctx context.Context, data LightResourceModel) error {
 	lightUpdate := light.LightUpdate{
 		ID:       data.Id.ValueString(),
-		Metadata: &update,
+		Metadata: createLightMetadataUpdate(data),
+		Powerup:  createLightPowerupObj(data.Powerup),
 	}
 	tflog.Info(ctx, "Updating Light", map[string]interface{}{"id": data.Id.ValueString(), "light": lightUpdate})
 	err := l.client.LightService().UpdateLight(ctx, lightUpdate)
 
 	tflog.Info(ctx, "Returning Updated Light", map[string]interface{}{"err": err, "id": data.Id.ValueString()})
+	return err
+}
 
-	if err != nil {
-		response.Diagnostics.AddError(
-			"Error updating light",
-			"Could not update light ID "+data.Id.ValueString()+": "+err.Error())
+func (l *LightResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data LightResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
 		return
 	}
-	// Save updated data into Terraform state
-	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
-}
 
-func (l *LightResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
-	response.Diagnostics.AddError("Not implemented", "Delete is not supported for this resource. Please delete the light from the app instead.")
-	return
+	switch data.OnDestroy.ValueString() {
+	case "reset":
+		owner, err := l.client.DeviceService().GetDevice(ctx, data.DeviceID.ValueString())
+		if err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				return
+			}
+			response.Diagnostics.AddError(
+				"Error resetting light",
+				"Could not read device ID "+data.DeviceID.ValueString()+": "+err.Error())
+			return
+		}
+		tflog.Info(ctx, "Resetting Light", map[string]interface{}{"id": data.Id.ValueString(), "name": owner.ProductData.ProductName})
+		err = l.client.LightService().UpdateLight(ctx, light.LightUpdate{
+			ID:       data.Id.ValueString(),
+			Metadata: resetLightMetadataUpdate(owner.ProductData),
+		})
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			response.Diagnostics.AddError(
+				"Error resetting light",
+				"Could not reset light ID "+data.Id.ValueString()+": "+err.Error())
+		}
+	case "unpair":
+		tflog.Info(ctx, "Unpairing Light", map[string]interface{}{"id": data.Id.ValueString(), "device_id": data.DeviceID.ValueString()})
+		err := l.client.DeviceService().DeleteDevice(ctx, data.DeviceID.ValueString())
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			response.Diagnostics.AddError(
+				"Error unpairing light",
+				"Could not delete device ID "+data.DeviceID.ValueString()+": "+err.Error())
+		}
+	}
 }
 
 func (l *LightResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	matched, err := regexp.MatchString(`^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$`, request.ID)
-	if err != nil {
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
-	} else if !matched {
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
-	}
-
-	lightID, err := l.client.GetLightIDForMacAddress(request.ID)
-	if err != nil {
-		response.Diagnostics.AddError("Error importing light", "Could not find light with MAC address "+request.ID+": "+err.Error())
-		return
+	id := request.ID
+	if macAddressRegex.MatchString(request.ID) {
+		lightID, err := l.client.GetLightIDForMacAddress(request.ID)
+		if err != nil {
+			response.Diagnostics.AddError("Error importing light", "Could not find light with MAC address "+request.ID+": "+err.Error())
+			return
+		}
+		id = lightID
 	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), resource.ImportStateRequest{
-		ID:                 lightID,
+		ID:                 id,
 		ClientCapabilities: request.ClientCapabilities,
 	}, response)
-
+	// An imported light gets the default on_destroy, so that it does not show as a change.
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("on_destroy"), "forget")...)
 }
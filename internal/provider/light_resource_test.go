@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	device2 "github.com/richseviora/huego/pkg/resources/device"
+)
+
+func TestLightMetadataUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update *lightMetadataUpdate
+		want   string
+	}{
+		{
+			name: "update",
+			update: createLightMetadataUpdate(LightResourceModel{
+				Name:      types.StringValue("Desk"),
+				Function:  types.StringNull(),
+				Archetype: types.StringValue("desk_lamp"),
+			}),
+			want: `{"name":"Desk","function":"decorative","archetype":"desk_lamp"}`,
+		},
+		{
+			name:   "update without archetype",
+			update: createLightMetadataUpdate(LightResourceModel{Name: types.StringValue("Desk"), Function: types.StringValue("functional")}),
+			want:   `{"name":"Desk","function":"functional"}`,
+		},
+		{
+			name:   "reset",
+			update: resetLightMetadataUpdate(device2.ProductData{ProductName: "Hue color lamp", ProductArchetype: "sultan_bulb"}),
+			want:   `{"name":"Hue color lamp","function":"decorative","archetype":"sultan_bulb"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.update)
+			if err != nil {
+				t.Fatalf("could not marshal the update: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("metadata update = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
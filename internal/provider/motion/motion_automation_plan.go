@@ -0,0 +1,308 @@
+package motion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"terraform-provider-philips/internal/provider/behavior"
+)
+
+var (
+	_ resource.ResourceWithValidateConfig = &MotionAutomationResource{}
+	_ resource.ResourceWithModifyPlan     = &MotionAutomationResource{}
+)
+
+func (m *MotionAutomationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data MotionAutomationResourceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		// Values that are unknown until apply are validated once they are known.
+		return
+	}
+	for i, timeSlot := range data.TimeSlots {
+		resp.Diagnostics.Append(validateTimeSlotStartTime(path.Root("time_slots").AtListIndex(i), timeSlot)...)
+		if timeSlot.AfterState.ValueString() == legacyAfterStateAllOff {
+			resp.Diagnostics.AddAttributeWarning(path.Root("time_slots").AtListIndex(i).AtName("after_state"), "Deprecated Attribute Value",
+				"The after_state \""+legacyAfterStateAllOff+"\" is deprecated, use \""+afterStateAllOff+"\" instead.")
+		}
+	}
+	resp.Diagnostics.Append(validateTimeSlotOrder(data.TimeSlots)...)
+	resp.Diagnostics.Append(validateDaylightSensitivity(data)...)
+}
+
+// validateTimeSlotStartTime checks that a fixed time of day has an hour and minute, and that a solar
+// time does not.
+func validateTimeSlotStartTime(slotPath path.Path, t TimeSlot) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if t.Type.IsUnknown() {
+		return diags
+	}
+	// The type defaults to a fixed time of day.
+	if t.Type.IsNull() || t.Type.ValueString() == startTimeTypeTime {
+		if t.Hour.IsNull() || t.Minute.IsNull() {
+			diags.AddAttributeError(slotPath, "Invalid Time Slot",
+				"hour and minute are required when the time slot type is \"time\".")
+		}
+		if !t.OffsetMinutes.IsNull() {
+			diags.AddAttributeError(slotPath.AtName("offset_minutes"), "Invalid Time Slot",
+				"offset_minutes can only be set when the time slot type is \"sunrise\" or \"sunset\".")
+		}
+		return diags
+	}
+	if !t.Hour.IsNull() || !t.Minute.IsNull() {
+		diags.AddAttributeError(slotPath, "Invalid Time Slot",
+			fmt.Sprintf("hour and minute can only be set when the time slot type is \"time\", use offset_minutes to start relative to the %s.", t.Type.ValueString()))
+	}
+	return diags
+}
+
+// validateTimeSlotOrder checks that fixed time slots are sorted by their time of day, and that no two time slots
+// start at the same time. The bridge rejects both, but only reports a generic error. The time slots form a daily
+// cycle, so they may wrap past midnight once, such as a night slot at 22:00 followed by a morning slot at 06:00.
+func validateTimeSlotOrder(timeSlots []TimeSlot) diag.Diagnostics {
+	var diags diag.Diagnostics
+	seen := map[string]int{}
+	previous, previousIndex := -1, -1
+	first, firstIndex := -1, -1
+	wrapped := false
+	for i, t := range timeSlots {
+		slotPath := path.Root("time_slots").AtListIndex(i)
+		if t.Type.IsUnknown() || t.Hour.IsUnknown() || t.Minute.IsUnknown() || t.OffsetMinutes.IsUnknown() {
+			continue
+		}
+		var key string
+		if t.Type.IsNull() || t.Type.ValueString() == startTimeTypeTime {
+			if t.Hour.IsNull() || t.Minute.IsNull() {
+				// Reported by validateTimeSlotStartTime.
+				continue
+			}
+			minutes := int(t.Hour.ValueInt32())*60 + int(t.Minute.ValueInt32())
+			if previousIndex >= 0 && minutes < previous {
+				if wrapped {
+					diags.AddAttributeError(slotPath, "Unsorted Time Slots",
+						fmt.Sprintf("The time slot starts at %02d:%02d, before time slot %d. Time slots must be sorted by their start time, and may only wrap past midnight once.", minutes/60, minutes%60, previousIndex))
+				}
+				wrapped = true
+			}
+			if wrapped && minutes > first {
+				diags.AddAttributeError(slotPath, "Unsorted Time Slots",
+					fmt.Sprintf("The time slot starts at %02d:%02d after wrapping past midnight, which is later than time slot %d at %02d:%02d. Time slots must be sorted by their start time.", minutes/60, minutes%60, firstIndex, first/60, first%60))
+			}
+			if firstIndex < 0 {
+				first, firstIndex = minutes, i
+			}
+			previous, previousIndex = minutes, i
+			key = fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+		} else {
+			key = fmt.Sprintf("%s%+d minutes", t.Type.ValueString(), t.OffsetMinutes.ValueInt32())
+		}
+		if other, ok := seen[key]; ok {
+			diags.AddAttributeError(slotPath, "Duplicate Time Slot",
+				fmt.Sprintf("The time slot starts at %s, the same as time slot %d.", key, other))
+			continue
+		}
+		seen[key] = i
+	}
+	return diags
+}
+
+// validateDaylightSensitivity checks that the light level at which the area is considered bright can be reported by the sensor.
+func validateDaylightSensitivity(data MotionAutomationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.DarkThreshold.IsNull() || data.DarkThreshold.IsUnknown() || data.DaylightOffset.IsUnknown() {
+		return diags
+	}
+	offset := int32(defaultDaylightOffset)
+	if !data.DaylightOffset.IsNull() {
+		offset = data.DaylightOffset.ValueInt32()
+	}
+	if int(data.DarkThreshold.ValueInt32())+int(offset) > maxLightLevel {
+		diags.AddAttributeError(path.Root("dark_threshold"), "Invalid Daylight Sensitivity",
+			fmt.Sprintf("The dark threshold of %d plus the daylight offset of %d exceeds the highest light level of %d, so the area would never be considered bright.", data.DarkThreshold.ValueInt32(), offset, maxLightLevel))
+	}
+	return diags
+}
+
+func (m *MotionAutomationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || m.client == nil {
+		return
+	}
+	var data MotionAutomationResourceModel
+	if diags := req.Plan.Get(ctx, &data); diags.HasError() {
+		// Values that are unknown until apply cannot be validated at plan time.
+		return
+	}
+
+	for i, source := range data.Sources {
+		resp.Diagnostics.Append(m.validateMotionSource(ctx, path.Root("sources").AtListIndex(i), source)...)
+	}
+	resp.Diagnostics.Append(m.validateSceneTargets(ctx, data)...)
+	if behavior.PlanKnownExceptID(req.Plan) {
+		resp.Diagnostics.Append(m.validateScriptConfiguration(data)...)
+	}
+
+	var timeSlots types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("time_slots"), &timeSlots)...)
+	if !timeSlots.IsUnknown() {
+		resp.Diagnostics.Append(m.validateSolarTimeSlots(ctx, data)...)
+	}
+}
+
+// validateSolarTimeSlots checks that the bridge knows its location, which it needs to calculate sunrise and sunset.
+func (m *MotionAutomationResource) validateSolarTimeSlots(ctx context.Context, data MotionAutomationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var solarSlots []int
+	for i, timeSlot := range data.TimeSlots {
+		if !timeSlot.Type.IsUnknown() && timeSlot.Type.ValueString() != startTimeTypeTime {
+			solarSlots = append(solarSlots, i)
+		}
+	}
+	if len(solarSlots) == 0 {
+		return diags
+	}
+	location, err := m.client.GeolocationService().GetGeolocation(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error reading bridge location",
+			"Could not read the bridge geolocation: "+err.Error())
+		return diags
+	}
+	if location.IsConfigured {
+		return diags
+	}
+	for _, i := range solarSlots {
+		diags.AddAttributeError(path.Root("time_slots").AtListIndex(i).AtName("type"), "Bridge Location Not Configured",
+			fmt.Sprintf("The time slot starts at %s, which requires the location of the bridge to be configured. Set the location in the Hue app, or use a fixed time of day.", data.TimeSlots[i].Type.ValueString()))
+	}
+	return diags
+}
+
+// validateMotionSource checks that the source exists on the bridge with the configured type.
+func (m *MotionAutomationResource) validateMotionSource(ctx context.Context, sourcePath path.Path, source Reference) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if source.Id.IsUnknown() || source.Type.IsUnknown() {
+		return diags
+	}
+	sourceType, err := m.client.GetMotionSourceType(ctx, source.Id.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading motion sources",
+			"Could not read motion source ID "+source.Id.ValueString()+": "+err.Error())
+		return diags
+	}
+	if sourceType == "" {
+		diags.AddAttributeError(sourcePath.AtName("id"), "Motion Source Not Found",
+			fmt.Sprintf("The bridge has no %s with ID %s. Grouped motion sources require a bridge firmware that supports them.", source.Type.ValueString(), source.Id.ValueString()))
+	} else if sourceType != source.Type.ValueString() {
+		diags.AddAttributeError(sourcePath.AtName("type"), "Motion Source Type Mismatch",
+			fmt.Sprintf("The motion source %s is a %s, not a %s.", source.Id.ValueString(), sourceType, source.Type.ValueString()))
+	}
+	return diags
+}
+
+// validateSceneTargets checks that every recalled scene belongs to one of the targets, as the bridge only recalls
+// scenes of the rooms and zones the automation targets.
+func (m *MotionAutomationResource) validateSceneTargets(ctx context.Context, data MotionAutomationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	targets := map[string]bool{}
+	for _, target := range data.Targets {
+		if target.Id.IsUnknown() {
+			return diags
+		}
+		targets[target.Id.ValueString()] = true
+	}
+	for i, timeSlot := range data.TimeSlots {
+		for j, sceneReference := range timeSlot.Scenes {
+			if sceneReference.Id.IsUnknown() {
+				continue
+			}
+			scenePath := path.Root("time_slots").AtListIndex(i).AtName("scenes").AtListIndex(j).AtName("id")
+			sceneData, err := m.client.SceneService().GetScene(ctx, sceneReference.Id.ValueString())
+			if err != nil {
+				if errors.Is(err, client.ErrNotFound) {
+					diags.AddAttributeError(scenePath, "Scene Not Found",
+						"The bridge has no scene with ID "+sceneReference.Id.ValueString()+".")
+					continue
+				}
+				diags.AddError(
+					"Error reading scene",
+					"Could not read scene ID "+sceneReference.Id.ValueString()+": "+err.Error())
+				return diags
+			}
+			if !targets[sceneData.Group.RID] {
+				diags.AddAttributeError(scenePath, "Scene Not In Target",
+					fmt.Sprintf("The scene belongs to the %s %s, which is not one of the targets of the automation.", sceneData.Group.RType, sceneData.Group.RID))
+			}
+		}
+	}
+	return diags
+}
+
+// validateScriptConfiguration validates the configuration sent to the bridge against the configuration schema of
+// the motion sensor behavior script.
+func (m *MotionAutomationResource) validateScriptConfiguration(data MotionAutomationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	script, err := m.client.GetBehaviorScriptForMetadataName(motionSensorScriptName)
+	if err != nil {
+		diags.AddError(
+			"Error reading behavior script",
+			"Could not find the motion sensor behavior script: "+err.Error())
+		return diags
+	}
+	if len(script.ConfigurationSchema) == 0 {
+		return diags
+	}
+	configuration, err := withoutNullFields(createConfiguration(data))
+	var violations []string
+	if err == nil {
+		violations, err = behavior.ValidateConfiguration(script.ConfigurationSchema, configuration)
+	}
+	if err != nil {
+		// An unsupported schema must not prevent the plan, the bridge still validates the configuration.
+		diags.AddWarning("Motion Automation Not Validated",
+			"Could not validate the configuration against the motion sensor behavior script: "+err.Error())
+		return diags
+	}
+	for _, violation := range violations {
+		diags.AddError("Invalid Motion Automation Configuration",
+			"The motion sensor behavior script rejects the configuration: "+violation)
+	}
+	return diags
+}
+
+// withoutNullFields encodes the configuration and drops the object fields that are null, so that unset optional
+// fields, such as the source of an automation with several sources, are validated as absent.
+func withoutNullFields(configuration any) (any, error) {
+	encoded, err := json.Marshal(configuration)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode configuration: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, fmt.Errorf("could not decode configuration: %w", err)
+	}
+	return dropNullFields(value), nil
+}
+
+func dropNullFields(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for name, field := range value {
+			if field == nil {
+				delete(value, name)
+				continue
+			}
+			value[name] = dropNullFields(field)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = dropNullFields(item)
+		}
+	}
+	return value
+}
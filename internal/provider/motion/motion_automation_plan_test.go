@@ -0,0 +1,234 @@
+package motion
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/geolocation"
+	"terraform-provider-philips/internal/provider/device"
+)
+
+func TestValidateTimeSlotStartTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeSlot TimeSlot
+		wantErr  bool
+	}{
+		{
+			name:     "fixed time",
+			timeSlot: TimeSlot{Type: types.StringValue("time"), Hour: types.Int32Value(7), Minute: types.Int32Value(0)},
+		},
+		{
+			name:     "default type",
+			timeSlot: TimeSlot{Type: types.StringNull(), Hour: types.Int32Value(7), Minute: types.Int32Value(0)},
+		},
+		{
+			name:     "fixed time without minute",
+			timeSlot: TimeSlot{Type: types.StringValue("time"), Hour: types.Int32Value(7)},
+			wantErr:  true,
+		},
+		{
+			name:     "fixed time with offset",
+			timeSlot: TimeSlot{Type: types.StringValue("time"), Hour: types.Int32Value(7), Minute: types.Int32Value(0), OffsetMinutes: types.Int32Value(30)},
+			wantErr:  true,
+		},
+		{
+			name:     "sunset with offset",
+			timeSlot: TimeSlot{Type: types.StringValue("sunset"), OffsetMinutes: types.Int32Value(-30)},
+		},
+		{
+			name:     "sunrise with hour",
+			timeSlot: TimeSlot{Type: types.StringValue("sunrise"), Hour: types.Int32Value(7)},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateTimeSlotStartTime(path.Root("time_slots").AtListIndex(0), tt.timeSlot)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validation errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateStartTime(t *testing.T) {
+	sunset := createStartTime(TimeSlot{Type: types.StringValue("sunset"), OffsetMinutes: types.Int32Value(-30)})
+	if sunset.Type != "sunset" || sunset.Offset == nil || sunset.Offset.Minutes != -30 {
+		t.Errorf("expected a sunset start time with a -30 minute offset, got %+v", sunset)
+	}
+	fixed := createStartTime(TimeSlot{Type: types.StringValue("time"), Hour: types.Int32Value(22), Minute: types.Int32Value(15)})
+	if fixed.Type != "time" || fixed.Time.Hour != 22 || fixed.Time.Minute != 15 || fixed.Offset != nil {
+		t.Errorf("expected a fixed start time of 22:15, got %+v", fixed)
+	}
+}
+
+func TestValidateTimeSlotOrder(t *testing.T) {
+	fixed := func(hour, minute int32) TimeSlot {
+		return TimeSlot{Type: types.StringValue("time"), Hour: types.Int32Value(hour), Minute: types.Int32Value(minute), OffsetMinutes: types.Int32Null()}
+	}
+	solar := func(kind string, offset int32) TimeSlot {
+		return TimeSlot{Type: types.StringValue(kind), Hour: types.Int32Null(), Minute: types.Int32Null(), OffsetMinutes: types.Int32Value(offset)}
+	}
+	tests := []struct {
+		name      string
+		timeSlots []TimeSlot
+		wantErr   bool
+	}{
+		{
+			name:      "sorted",
+			timeSlots: []TimeSlot{fixed(7, 0), solar("sunset", -30), fixed(22, 0)},
+		},
+		{
+			name:      "wrapping past midnight",
+			timeSlots: []TimeSlot{fixed(22, 0), fixed(6, 0)},
+		},
+		{
+			name:      "wrapping past midnight after several slots",
+			timeSlots: []TimeSlot{fixed(7, 0), fixed(18, 0), solar("sunset", 0), fixed(1, 30)},
+		},
+		{
+			name:      "wrapping twice",
+			timeSlots: []TimeSlot{fixed(22, 0), fixed(6, 0), fixed(20, 0), fixed(5, 0)},
+			wantErr:   true,
+		},
+		{
+			name:      "wrapping past the first slot",
+			timeSlots: []TimeSlot{fixed(7, 0), fixed(22, 0), fixed(8, 0)},
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate fixed time",
+			timeSlots: []TimeSlot{fixed(7, 0), fixed(7, 0)},
+			wantErr:   true,
+		},
+		{
+			name:      "duplicate solar time",
+			timeSlots: []TimeSlot{solar("sunrise", 15), solar("sunrise", 15)},
+			wantErr:   true,
+		},
+		{
+			name:      "same solar event with different offsets",
+			timeSlots: []TimeSlot{solar("sunset", -30), solar("sunset", 30)},
+		},
+		{
+			name:      "unknown hour",
+			timeSlots: []TimeSlot{fixed(22, 0), {Type: types.StringValue("time"), Hour: types.Int32Unknown(), Minute: types.Int32Value(0), OffsetMinutes: types.Int32Null()}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateTimeSlotOrder(tt.timeSlots)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validation errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDaylightSensitivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		darkThreshold  types.Int32
+		daylightOffset types.Int32
+		wantErr        bool
+	}{
+		{name: "within range", darkThreshold: types.Int32Value(12000), daylightOffset: types.Int32Value(7000)},
+		{name: "default offset", darkThreshold: types.Int32Value(58535), daylightOffset: types.Int32Null()},
+		{name: "default offset out of range", darkThreshold: types.Int32Value(60000), daylightOffset: types.Int32Null(), wantErr: true},
+		{name: "out of range", darkThreshold: types.Int32Value(40000), daylightOffset: types.Int32Value(30000), wantErr: true},
+		{name: "unknown offset", darkThreshold: types.Int32Value(60000), daylightOffset: types.Int32Unknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateDaylightSensitivity(MotionAutomationResourceModel{DarkThreshold: tt.darkThreshold, DaylightOffset: tt.daylightOffset})
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validation errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+// geolocationClient is a client that only serves the bridge geolocation, and counts how often it is read.
+type geolocationClient struct {
+	device.ClientWithLightIDCache
+	configured bool
+	reads      int
+}
+
+func (g *geolocationClient) GeolocationService() geolocation.Service {
+	return g
+}
+
+func (g *geolocationClient) GetGeolocation(_ context.Context) (*geolocation.Data, error) {
+	g.reads++
+	return &geolocation.Data{ID: "geolocation", IsConfigured: g.configured}, nil
+}
+
+func TestValidateSolarTimeSlots(t *testing.T) {
+	timeSlots := []TimeSlot{
+		{Type: types.StringValue("sunset")},
+		{Type: types.StringValue("time")},
+		{Type: types.StringUnknown()},
+		{Type: types.StringValue("sunrise")},
+	}
+	tests := []struct {
+		name       string
+		configured bool
+		timeSlots  []TimeSlot
+		wantErrs   int
+		wantReads  int
+	}{
+		{name: "location configured", configured: true, timeSlots: timeSlots, wantReads: 1},
+		{name: "location not configured", timeSlots: timeSlots, wantErrs: 2, wantReads: 1},
+		{name: "fixed times only", timeSlots: timeSlots[1:3]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &geolocationClient{configured: tt.configured}
+			m := &MotionAutomationResource{client: c}
+			diags := m.validateSolarTimeSlots(context.Background(), MotionAutomationResourceModel{TimeSlots: tt.timeSlots})
+			if diags.ErrorsCount() != tt.wantErrs {
+				t.Errorf("validateSolarTimeSlots() errors = %v, want %d", diags, tt.wantErrs)
+			}
+			if c.reads != tt.wantReads {
+				t.Errorf("read the geolocation %d times, want %d", c.reads, tt.wantReads)
+			}
+		})
+	}
+}
+
+func TestWithoutNullFields(t *testing.T) {
+	type reference struct {
+		RID string `json:"rid"`
+	}
+	configuration := struct {
+		Source  *reference  `json:"source"`
+		Sources []reference `json:"sources"`
+		Where   []struct {
+			Group *reference `json:"group"`
+			Zone  *reference `json:"zone"`
+		} `json:"where"`
+	}{
+		Sources: []reference{{RID: "sensor"}},
+	}
+	configuration.Where = append(configuration.Where, struct {
+		Group *reference `json:"group"`
+		Zone  *reference `json:"zone"`
+	}{Group: &reference{RID: "room"}})
+
+	got, err := withoutNullFields(configuration)
+	if err != nil {
+		t.Fatalf("withoutNullFields() error = %v", err)
+	}
+	want := map[string]any{
+		"sources": []any{map[string]any{"rid": "sensor"}},
+		"where":   []any{map[string]any{"group": map[string]any{"rid": "room"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withoutNullFields() = %v, want %v", got, want)
+	}
+}
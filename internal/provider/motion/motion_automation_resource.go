@@ -4,21 +4,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/richseviora/huego/pkg/resources/behavior_instance"
 	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/color"
 	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/light"
 	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
 )
 
+const startTimeTypeTime = "time"
+
+// motionSensorScriptName is the name of the behavior script that runs motion automations.
+const motionSensorScriptName = "Motion Sensor"
+
+// defaultDaylightOffset is the daylight offset used by the Hue app.
+const defaultDaylightOffset = 7000
+
+// maxLightLevel is the highest light level reported by a motion sensor.
+const maxLightLevel = 65535
+
+// legacySensorSourceType is the source type written for the deprecated sensor_id attribute.
+const legacySensorSourceType = "sensor"
+
+const (
+	afterStateAllOff = "all_off"
+	// legacyAfterStateAllOff is the misspelled after state accepted before all_off.
+	legacyAfterStateAllOff = "all_of"
+)
+
+var motionSourceTypes = []string{"motion", "grouped_motion", "convenience_area_motion"}
+
 var (
 	_ resource.ResourceWithImportState = &MotionAutomationResource{}
 	_ resource.ResourceWithConfigure   = &MotionAutomationResource{}
@@ -35,29 +65,49 @@ type Reference struct {
 }
 
 type TimeSlot struct {
-	Hour   types.Int32 `tfsdk:"hour"`
-	Minute types.Int32 `tfsdk:"minute"`
-	Scenes []Reference `tfsdk:"scenes"`
+	// The start time type, either a fixed time of day or relative to the sunrise or sunset.
+	Type          types.String `tfsdk:"type"`
+	Hour          types.Int32  `tfsdk:"hour"`
+	Minute        types.Int32  `tfsdk:"minute"`
+	OffsetMinutes types.Int32  `tfsdk:"offset_minutes"`
+	Scenes        []Reference  `tfsdk:"scenes"`
+	// Light settings applied instead of recalling a scene.
+	Light *TimeSlotLightModel `tfsdk:"light"`
 	// The delay period in minutes. 0 to 60.
 	AfterDelay types.Int32  `tfsdk:"after_delay"`
 	AfterState types.String `tfsdk:"after_state"`
 }
 
+type TimeSlotLightModel struct {
+	Brightness       huetypes.Brightness `tfsdk:"brightness"`
+	ColorTemperature huetypes.Kelvin     `tfsdk:"color_temperature"`
+	Color            *TimeSlotColorModel `tfsdk:"color"`
+	// The transition duration in milliseconds.
+	TransitionDuration types.Int64 `tfsdk:"transition_duration"`
+}
+
+type TimeSlotColorModel struct {
+	X huetypes.XY `tfsdk:"x"`
+	Y huetypes.XY `tfsdk:"y"`
+}
+
 type MotionAutomationResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	SensorID      types.String `tfsdk:"sensor_id"`
-	Targets       []Reference  `tfsdk:"targets"`
-	DarkThreshold types.Int32  `tfsdk:"dark_threshold"`
-	TimeSlots     []TimeSlot   `tfsdk:"time_slots"`
-	Enabled       types.Bool   `tfsdk:"enabled"`
-	Name          types.String `tfsdk:"name"`
+	ID             types.String `tfsdk:"id"`
+	SensorID       types.String `tfsdk:"sensor_id"`
+	Sources        []Reference  `tfsdk:"sources"`
+	Targets        []Reference  `tfsdk:"targets"`
+	DarkThreshold  types.Int32  `tfsdk:"dark_threshold"`
+	DaylightOffset types.Int32  `tfsdk:"daylight_offset"`
+	TimeSlots      []TimeSlot   `tfsdk:"time_slots"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Name           types.String `tfsdk:"name"`
 }
 
 func NewMotionAutomationResource() resource.Resource {
 	return &MotionAutomationResource{}
 }
 
-func (m MotionAutomationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+func (m *MotionAutomationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
@@ -72,11 +122,11 @@ func (m MotionAutomationResource) Configure(ctx context.Context, req resource.Co
 	m.client = client
 }
 
-func (m MotionAutomationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+func (m *MotionAutomationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_motion_automation"
 }
 
-func (m MotionAutomationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (m *MotionAutomationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -84,7 +134,6 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 				Description:         "",
 				MarkdownDescription: "",
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
-				Validators:          []validator.String{stringvalidator.OneOf("previous_state", "all_of")},
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
@@ -94,8 +143,32 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 				},
 			},
 			"sensor_id": schema.StringAttribute{
-				Required:    true,
-				Description: "The ID of the motion sensor.",
+				Optional:           true,
+				Description:        "The ID of the motion sensor.",
+				DeprecationMessage: "Use sources instead, which also supports grouped motion.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("sources")),
+				},
+			},
+			"sources": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The motion sources that trigger the automation. Motion on any of them triggers it, so several sensors act as one.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Required:    true,
+							Description: "The ID of the motion source.",
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "The type of the motion source: `motion` for the motion service of a sensor, or `grouped_motion` and `convenience_area_motion` for the combined motion of a room, zone or area. Grouped sources require a recent bridge firmware.",
+							Validators:  []validator.String{stringvalidator.OneOf(motionSourceTypes...)},
+						},
+					},
+				},
 			},
 			"targets": schema.ListNestedAttribute{
 				Required:    true,
@@ -116,7 +189,14 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 			},
 			"dark_threshold": schema.Int32Attribute{
 				Required:   true,
-				Validators: []validator.Int32{int32validator.Between(0, 65535)},
+				Validators: []validator.Int32{int32validator.Between(0, maxLightLevel)},
+			},
+			"daylight_offset": schema.Int32Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int32default.StaticInt32(defaultDaylightOffset),
+				Description: "The light level above the dark threshold at which the area is considered bright enough to not turn on the lights. Defaults to 7000.",
+				Validators:  []validator.Int32{int32validator.Between(0, maxLightLevel)},
 			},
 			"enabled": schema.BoolAttribute{
 				Required:    true,
@@ -127,16 +207,28 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 				Description: "The time slots to trigger the automation.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(startTimeTypeTime),
+							Description: "The start time type: `time` for a fixed time of day, or `sunrise` and `sunset` for a time relative to the sun. Solar times require the bridge location to be configured.",
+							Validators:  []validator.String{stringvalidator.OneOf(startTimeTypeTime, "sunrise", "sunset")},
+						},
 						"hour": schema.Int32Attribute{
-							Required:    true,
-							Description: "The hour of the time slot.",
+							Optional:    true,
+							Description: "The hour of the time slot. Required when the type is `time`.",
 							Validators:  []validator.Int32{int32validator.Between(0, 23)},
 						},
 						"minute": schema.Int32Attribute{
-							Required:    true,
-							Description: "The minute of the time slot.",
+							Optional:    true,
+							Description: "The minute of the time slot. Required when the type is `time`.",
 							Validators:  []validator.Int32{int32validator.Between(0, 59)},
 						},
+						"offset_minutes": schema.Int32Attribute{
+							Optional:    true,
+							Description: "The offset in minutes from the sunrise or sunset, negative for before. Only used when the type is `sunrise` or `sunset`.",
+							Validators:  []validator.Int32{int32validator.Between(-180, 180)},
+						},
 						"after_delay": schema.Int32Attribute{
 							Required:    true,
 							Description: "The delay in minutes after the time slot has been triggered.",
@@ -145,13 +237,17 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 							},
 						},
 						"after_state": schema.StringAttribute{
-							Required:    true,
-							Description: "The state to return the lights to after the delay period.",
-							Validators:  []validator.String{stringvalidator.OneOf("previous_state", "all_of")},
+							Optional:    true,
+							Description: "The state to return the lights to after the delay period: `previous_state` or `all_off`. The lights stay on when not set. `all_of` is a deprecated spelling of `all_off`.",
+							Validators:  []validator.String{stringvalidator.OneOf("previous_state", afterStateAllOff, legacyAfterStateAllOff)},
 						},
 						"scenes": schema.ListNestedAttribute{
-							Required:    true,
-							Description: "The IDs of the target scenes to activate when the sensor is triggered.",
+							Optional:    true,
+							Description: "The IDs of the target scenes to activate when the sensor is triggered. Exactly one of `scenes` or `light` must be set.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("light")),
+							},
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"id": schema.StringAttribute{
@@ -166,6 +262,11 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 								},
 							},
 						},
+						"light": schema.SingleNestedAttribute{
+							Optional:    true,
+							Description: "The light settings to apply to the targets when the sensor is triggered, instead of recalling a scene. At least one of `brightness`, `color_temperature` or `color` must be set.",
+							Attributes:  timeSlotLightAttributes(),
+						},
 					},
 				},
 			},
@@ -173,13 +274,69 @@ func (m MotionAutomationResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
-func (m MotionAutomationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func timeSlotLightAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"brightness": schema.Float64Attribute{
+			Optional:    true,
+			CustomType:  huetypes.BrightnessType{},
+			Description: "The brightness to apply to the targets from 0 to 100.",
+			Validators: []validator.Float64{
+				float64validator.Between(0, 100),
+				float64validator.AtLeastOneOf(
+					path.MatchRelative().AtParent().AtName("color_temperature"),
+					path.MatchRelative().AtParent().AtName("color"),
+				),
+			},
+		},
+		"color_temperature": schema.Int32Attribute{
+			Optional:    true,
+			CustomType:  huetypes.KelvinType{},
+			Description: "The color temperature to apply to the targets from 2000 K to 6500 K. The bridge stores color temperatures in mirek, so the value may read back a few kelvin off.",
+			Validators: []validator.Int32{
+				int32validator.Between(2000, 6500),
+				int32validator.ConflictsWith(path.MatchRelative().AtParent().AtName("color")),
+			},
+		},
+		"color": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The xy color to apply to the targets.",
+			Attributes: map[string]schema.Attribute{
+				"x": schema.Float64Attribute{
+					Required:    true,
+					CustomType:  huetypes.XYType{},
+					Description: "The x value of the color.",
+					Validators:  []validator.Float64{float64validator.Between(0, 1)},
+				},
+				"y": schema.Float64Attribute{
+					Required:    true,
+					CustomType:  huetypes.XYType{},
+					Description: "The y value of the color.",
+					Validators:  []validator.Float64{float64validator.Between(0, 1)},
+				},
+			},
+		},
+		"transition_duration": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The transition duration in milliseconds.",
+			Validators:  []validator.Int64{int64validator.AtLeast(0)},
+		},
+	}
+}
+
+func (m *MotionAutomationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data MotionAutomationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	scriptId, err := m.client.GetBehaviorScriptIDForMetadataName("Motion Sensor")
+	scriptId, err := m.client.GetBehaviorScriptIDForMetadataName(motionSensorScriptName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating motion automation",
+			"Could not find the motion sensor behavior script: "+err.Error(),
+		)
+		return
+	}
 	create := SetCreateFromBody(data, scriptId)
 
 	response, err := m.client.BehaviorInstanceService().CreateBehaviorInstance(ctx, create)
@@ -194,7 +351,7 @@ func (m MotionAutomationResource) Create(ctx context.Context, req resource.Creat
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (m MotionAutomationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+func (m *MotionAutomationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data MotionAutomationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -210,12 +367,13 @@ func (m MotionAutomationResource) Read(ctx context.Context, req resource.ReadReq
 			"Error reading motion automation",
 			"Could not read motion automation ID "+data.ID.ValueString()+": "+err.Error(),
 		)
+		return
 	}
-	data = *SetModelFromBody(*resource)
+	data = *keepLegacyAfterState(data, keepLegacySensorID(data, SetModelFromBody(*resource)))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (m MotionAutomationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+func (m *MotionAutomationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data MotionAutomationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -233,7 +391,7 @@ func (m MotionAutomationResource) Update(ctx context.Context, req resource.Updat
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (m MotionAutomationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+func (m *MotionAutomationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data MotionAutomationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -251,7 +409,7 @@ func (m MotionAutomationResource) Delete(ctx context.Context, req resource.Delet
 	}
 }
 
-func (m MotionAutomationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+func (m *MotionAutomationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -263,29 +421,45 @@ func Map[T any, R any](input []T, mapper func(T) R) []R {
 	return result
 }
 
+// SetModelFromBody maps a behavior instance read from the bridge to the resource model, so that
+// reading and importing produce the same state as the configuration that created it.
 func SetModelFromBody(bi behavior_instance.Data) *MotionAutomationResourceModel {
 	m := &MotionAutomationResourceModel{
 		ID:       types.StringValue(bi.ID),
-		SensorID: types.StringValue(bi.Configuration.Source.RID),
+		Name:     types.StringValue(bi.Metadata.Name),
+		Enabled:  types.BoolValue(bi.Enabled),
+		SensorID: types.StringNull(),
+		Sources:  setSourcesFromBody(bi.Configuration),
 		Targets: Map(bi.Configuration.Where, func(t behavior_instance.Where) Reference {
 			return Reference{
 				Id:   types.StringValue(t.Group.RID),
 				Type: types.StringValue(t.Group.RType),
 			}
 		}),
-		DarkThreshold: types.Int32Value(int32(bi.Configuration.Settings.DaylightSensitivity.DarkThreshold)),
+		DarkThreshold:  types.Int32Value(int32(bi.Configuration.Settings.DaylightSensitivity.DarkThreshold)),
+		DaylightOffset: types.Int32Value(int32(bi.Configuration.Settings.DaylightSensitivity.Offset)),
 		TimeSlots: Map(bi.Configuration.When.Timeslots, func(t behavior_instance.TimeSlots) TimeSlot {
+			hour, minute, offset := types.Int32Null(), types.Int32Null(), types.Int32Null()
+			if t.StartTime.Type == startTimeTypeTime {
+				hour = types.Int32Value(int32(t.StartTime.Time.Hour))
+				minute = types.Int32Value(int32(t.StartTime.Time.Minute))
+			} else if t.StartTime.Offset != nil {
+				offset = types.Int32Value(int32(t.StartTime.Offset.Minutes))
+			}
+			// Time slots created in the Hue app can leave the lights on when there is no motion.
+			afterState := types.StringNull()
+			if len(t.OnNoMotion.RecallSingle) > 0 {
+				afterState = types.StringValue(t.OnNoMotion.RecallSingle[0].Action)
+			}
 			return TimeSlot{
-				Hour:   types.Int32Value(int32(t.StartTime.Time.Hour)),
-				Minute: types.Int32Value(int32(t.StartTime.Time.Minute)),
-				Scenes: Map(t.OnMotion.RecallSingle, func(t behavior_instance.RecallSingle) Reference {
-					return Reference{
-						Id:   types.StringValue(t.Action.Recall.RID),
-						Type: types.StringValue(t.Action.Recall.RType),
-					}
-				}),
-				AfterDelay: types.Int32Value(int32(t.OnNoMotion.After.Minutes)),
-				AfterState: types.StringValue(t.OnNoMotion.RecallSingle[0].Action),
+				Type:          types.StringValue(t.StartTime.Type),
+				Hour:          hour,
+				Minute:        minute,
+				OffsetMinutes: offset,
+				Scenes:        setScenesFromBody(t.OnMotion),
+				Light:         setLightFromBody(t.OnMotion),
+				AfterDelay:    types.Int32Value(int32(t.OnNoMotion.After.Minutes)),
+				AfterState:    afterState,
 			}
 		}),
 	}
@@ -293,123 +467,235 @@ func SetModelFromBody(bi behavior_instance.Data) *MotionAutomationResourceModel
 	return m
 }
 
-func SetCreateFromBody(model MotionAutomationResourceModel, scriptId string) behavior_instance.CreateRequest {
-	return behavior_instance.CreateRequest{
-		ScriptID: scriptId,
-		Configuration: behavior_instance.Configuration{
-			Settings: behavior_instance.Settings{
-				DaylightSensitivity: behavior_instance.DaylightSensitivity{
-					DarkThreshold: int(model.DarkThreshold.ValueInt32()),
-					Offset:        7000,
-				},
+// setSourcesFromBody returns the motion sources, which the bridge stores as a single source unless there are several.
+func setSourcesFromBody(configuration behavior_instance.Configuration) []Reference {
+	sources := configuration.Sources
+	if len(sources) == 0 && configuration.Source != nil {
+		sources = []common.Reference{*configuration.Source}
+	}
+	return Map(sources, func(s common.Reference) Reference {
+		return Reference{
+			Id:   types.StringValue(s.RID),
+			Type: types.StringValue(s.RType),
+		}
+	})
+}
+
+// keepLegacySensorID reads a single source back into sensor_id when the prior state still uses it.
+func keepLegacySensorID(prior MotionAutomationResourceModel, model *MotionAutomationResourceModel) *MotionAutomationResourceModel {
+	if prior.SensorID.IsNull() || len(model.Sources) != 1 {
+		return model
+	}
+	model.SensorID = model.Sources[0].Id
+	model.Sources = nil
+	return model
+}
+
+// keepLegacyAfterState keeps the deprecated all_of after state of the prior state, which the bridge stores as all_off.
+func keepLegacyAfterState(prior MotionAutomationResourceModel, model *MotionAutomationResourceModel) *MotionAutomationResourceModel {
+	for i := range model.TimeSlots {
+		if i < len(prior.TimeSlots) && prior.TimeSlots[i].AfterState.ValueString() == legacyAfterStateAllOff &&
+			model.TimeSlots[i].AfterState.ValueString() == afterStateAllOff {
+			model.TimeSlots[i].AfterState = prior.TimeSlots[i].AfterState
+		}
+	}
+	return model
+}
+
+func createAfterState(afterState types.String) string {
+	if afterState.ValueString() == legacyAfterStateAllOff {
+		return afterStateAllOff
+	}
+	return afterState.ValueString()
+}
+
+// createSources maps the motion sources. A single source is sent as the source of the script, several as its sources.
+func createSources(model MotionAutomationResourceModel) (*common.Reference, []common.Reference) {
+	if !model.SensorID.IsNull() {
+		return &common.Reference{
+			RID:   model.SensorID.ValueString(),
+			RType: legacySensorSourceType,
+		}, nil
+	}
+	sources := Map(model.Sources, func(s Reference) common.Reference {
+		return common.Reference{
+			RID:   s.Id.ValueString(),
+			RType: s.Type.ValueString(),
+		}
+	})
+	if len(sources) == 1 {
+		return &sources[0], nil
+	}
+	return nil, sources
+}
+
+// setScenesFromBody returns the scenes recalled on motion, or nil when the time slot sets the lights directly.
+func setScenesFromBody(onMotion behavior_instance.OnMotion) []Reference {
+	var scenes []Reference
+	for _, recall := range onMotion.RecallSingle {
+		if recall.Action.Recall == nil {
+			continue
+		}
+		scenes = append(scenes, Reference{
+			Id:   types.StringValue(recall.Action.Recall.RID),
+			Type: types.StringValue(recall.Action.Recall.RType),
+		})
+	}
+	return scenes
+}
+
+// setLightFromBody returns the light settings applied on motion, or nil when the time slot recalls scenes.
+func setLightFromBody(onMotion behavior_instance.OnMotion) *TimeSlotLightModel {
+	for _, recall := range onMotion.RecallSingle {
+		state := recall.Action.LightState
+		if state == nil {
+			continue
+		}
+		result := &TimeSlotLightModel{
+			Brightness:         huetypes.NewBrightnessNull(),
+			ColorTemperature:   huetypes.NewKelvinNull(),
+			TransitionDuration: types.Int64Null(),
+		}
+		if state.Dimming != nil {
+			result.Brightness = huetypes.NewBrightnessValue(state.Dimming.Brightness)
+		}
+		if state.ColorTemperature != nil {
+			result.ColorTemperature = huetypes.NewKelvinFromMirek(state.ColorTemperature.Mirek)
+		}
+		if state.Color != nil {
+			result.Color = &TimeSlotColorModel{
+				X: huetypes.NewXYValue(state.Color.XY.X),
+				Y: huetypes.NewXYValue(state.Color.XY.Y),
+			}
+		}
+		if state.Dynamics != nil {
+			result.TransitionDuration = types.Int64Value(int64(state.Dynamics.Duration))
+		}
+		return result
+	}
+	return nil
+}
+
+// createOnMotion maps what happens on motion, either recalling the scenes or setting the lights directly.
+func createOnMotion(t TimeSlot) behavior_instance.OnMotion {
+	onMotion := behavior_instance.OnMotion{
+		RecallSingle: Map[Reference, behavior_instance.RecallSingle](t.Scenes, func(s Reference) behavior_instance.RecallSingle {
+			return behavior_instance.RecallSingle{
+				Action: behavior_instance.Action{Recall: &common.Reference{
+					RID:   s.Id.ValueString(),
+					RType: s.Type.ValueString(),
+				}},
+			}
+		}),
+	}
+	if t.Light == nil {
+		return onMotion
+	}
+	state := &behavior_instance.LightState{}
+	if !t.Light.Brightness.IsNull() {
+		state.Dimming = &common.Dimming{Brightness: t.Light.Brightness.ValueFloat64()}
+	}
+	if !t.Light.ColorTemperature.IsNull() {
+		state.ColorTemperature = &light.ColorTemperature{Mirek: t.Light.ColorTemperature.Mirek()}
+	}
+	if t.Light.Color != nil {
+		state.Color = &light.Color{
+			XY: color.XYCoord{
+				X: t.Light.Color.X.ValueFloat64(),
+				Y: t.Light.Color.Y.ValueFloat64(),
 			},
-			Source: common.Reference{
-				RID:   model.SensorID.ValueString(),
-				RType: "sensor",
+		}
+	}
+	if !t.Light.TransitionDuration.IsNull() {
+		state.Dynamics = &behavior_instance.Dynamics{Duration: int(t.Light.TransitionDuration.ValueInt64())}
+	}
+	onMotion.RecallSingle = append(onMotion.RecallSingle, behavior_instance.RecallSingle{
+		Action: behavior_instance.Action{LightState: state},
+	})
+	return onMotion
+}
+
+// createStartTime maps the start time of a time slot. Solar start times carry an offset instead of a time of day.
+func createStartTime(t TimeSlot) behavior_instance.StartTime {
+	if t.Type.ValueString() != startTimeTypeTime {
+		startTime := behavior_instance.StartTime{Type: t.Type.ValueString()}
+		if !t.OffsetMinutes.IsNull() && !t.OffsetMinutes.IsUnknown() {
+			startTime.Offset = &behavior_instance.Offset{Minutes: int(t.OffsetMinutes.ValueInt32())}
+		}
+		return startTime
+	}
+	return behavior_instance.StartTime{
+		Time: behavior_instance.Time{
+			Hour:   int(t.Hour.ValueInt32()),
+			Minute: int(t.Minute.ValueInt32()),
+		},
+		Type: startTimeTypeTime,
+	}
+}
+
+// createOnNoMotion maps what happens once the delay after the last motion has passed.
+func createOnNoMotion(t TimeSlot) behavior_instance.OnNoMotion {
+	onNoMotion := behavior_instance.OnNoMotion{
+		After: behavior_instance.After{
+			Minutes: int(t.AfterDelay.ValueInt32()),
+		},
+	}
+	if !t.AfterState.IsNull() {
+		onNoMotion.RecallSingle = []behavior_instance.RecallSingleNoMotion{
+			{
+				Action: createAfterState(t.AfterState),
 			},
-			When: behavior_instance.When{
-				Timeslots: Map[TimeSlot](model.TimeSlots, func(t TimeSlot) behavior_instance.TimeSlots {
-					return behavior_instance.TimeSlots{
-						StartTime: behavior_instance.StartTime{
-							Time: behavior_instance.Time{
-								Hour:   int(t.Hour.ValueInt32()),
-								Minute: int(t.Minute.ValueInt32()),
-							},
-							Type: "time",
-						},
-						OnMotion: behavior_instance.OnMotion{
-							RecallSingle: Map[Reference, behavior_instance.RecallSingle](t.Scenes, func(s Reference) behavior_instance.RecallSingle {
-								return behavior_instance.RecallSingle{
-									Action: behavior_instance.Action{Recall: common.Reference{
-										RID:   s.Id.ValueString(),
-										RType: s.Type.ValueString(),
-									}},
-								}
-							}),
-						},
-						OnNoMotion: behavior_instance.OnNoMotion{
-							After: behavior_instance.After{
-								Minutes: int(t.AfterDelay.ValueInt32()),
-							},
-							RecallSingle: []behavior_instance.RecallSingleNoMotion{
-								{
-									Action: t.AfterState.ValueString(),
-								},
-							},
-						},
-					}
-				}),
+		}
+	}
+	return onNoMotion
+}
+
+// createConfiguration maps the model to the behavior instance configuration shared by create and update requests.
+func createConfiguration(model MotionAutomationResourceModel) behavior_instance.Configuration {
+	source, sources := createSources(model)
+	return behavior_instance.Configuration{
+		Settings: behavior_instance.Settings{
+			DaylightSensitivity: behavior_instance.DaylightSensitivity{
+				DarkThreshold: int(model.DarkThreshold.ValueInt32()),
+				Offset:        int(model.DaylightOffset.ValueInt32()),
 			},
-			Where: Map[Reference](model.Targets, func(t Reference) behavior_instance.Where {
-				return behavior_instance.Where{
-					Group: common.Reference{
-						RID:   t.Id.ValueString(),
-						RType: t.Type.ValueString(),
-					},
+		},
+		Source:  source,
+		Sources: sources,
+		When: behavior_instance.When{
+			Timeslots: Map[TimeSlot](model.TimeSlots, func(t TimeSlot) behavior_instance.TimeSlots {
+				return behavior_instance.TimeSlots{
+					StartTime:  createStartTime(t),
+					OnMotion:   createOnMotion(t),
+					OnNoMotion: createOnNoMotion(t),
 				}
 			}),
 		},
-		Enabled:  model.Enabled.ValueBool(),
-		Metadata: &behavior_instance.Metadata{Name: model.Name.ValueString()},
+		Where: Map[Reference](model.Targets, func(t Reference) behavior_instance.Where {
+			return behavior_instance.Where{
+				Group: common.Reference{
+					RID:   t.Id.ValueString(),
+					RType: t.Type.ValueString(),
+				},
+			}
+		}),
+	}
+}
+
+func SetCreateFromBody(model MotionAutomationResourceModel, scriptId string) behavior_instance.CreateRequest {
+	return behavior_instance.CreateRequest{
+		ScriptID:      scriptId,
+		Configuration: createConfiguration(model),
+		Enabled:       model.Enabled.ValueBool(),
+		Metadata:      &behavior_instance.Metadata{Name: model.Name.ValueString()},
 	}
 }
 
 func SetUpdateFromBody(model MotionAutomationResourceModel) behavior_instance.UpdateRequest {
+	configuration := createConfiguration(model)
 	return behavior_instance.UpdateRequest{
-		Configuration: &behavior_instance.Configuration{
-			Settings: behavior_instance.Settings{
-				DaylightSensitivity: behavior_instance.DaylightSensitivity{
-					DarkThreshold: int(model.DarkThreshold.ValueInt32()),
-					Offset:        7000,
-				},
-			},
-			Source: common.Reference{
-				RID:   model.SensorID.ValueString(),
-				RType: "sensor",
-			},
-			When: behavior_instance.When{
-				Timeslots: Map[TimeSlot](model.TimeSlots, func(t TimeSlot) behavior_instance.TimeSlots {
-					return behavior_instance.TimeSlots{
-						StartTime: behavior_instance.StartTime{
-							Time: behavior_instance.Time{
-								Hour:   int(t.Hour.ValueInt32()),
-								Minute: int(t.Minute.ValueInt32()),
-							},
-							Type: "time",
-						},
-						OnMotion: behavior_instance.OnMotion{
-							RecallSingle: Map[Reference, behavior_instance.RecallSingle](t.Scenes, func(s Reference) behavior_instance.RecallSingle {
-								return behavior_instance.RecallSingle{
-									Action: behavior_instance.Action{Recall: common.Reference{
-										RID:   s.Id.ValueString(),
-										RType: s.Type.ValueString(),
-									}},
-								}
-							}),
-						},
-						OnNoMotion: behavior_instance.OnNoMotion{
-							After: behavior_instance.After{
-								Minutes: int(t.AfterDelay.ValueInt32()),
-							},
-							RecallSingle: []behavior_instance.RecallSingleNoMotion{
-								{
-									Action: t.AfterState.ValueString(),
-								},
-							},
-						},
-					}
-				}),
-			},
-			Where: Map[Reference](model.Targets, func(t Reference) behavior_instance.Where {
-				return behavior_instance.Where{
-					Group: common.Reference{
-						RID:   t.Id.ValueString(),
-						RType: t.Type.ValueString(),
-					},
-				}
-			}),
-		},
-		Enabled:  model.Enabled.ValueBoolPointer(),
-		Metadata: &behavior_instance.Metadata{Name: model.Name.ValueString()},
+		Configuration: &configuration,
+		Enabled:       model.Enabled.ValueBoolPointer(),
+		Metadata:      &behavior_instance.Metadata{Name: model.Name.ValueString()},
 	}
 }
@@ -0,0 +1,283 @@
+package motion
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/behavior_instance"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+// Behavior instances as returned by the bridge for the motion sensor script.
+const (
+	hallwayMotionJSON = `{
+		"id": "4a9a6e3e-1f0c-4b43-9d64-2c1b4b0e8f11",
+		"type": "behavior_instance",
+		"script_id": "5d8c7a2e-0b1a-4f47-9c43-8f0b0c3c9e10",
+		"enabled": true,
+		"configuration": {
+			"settings": {"daylight_sensitivity": {"dark_threshold": 12000, "offset": 5000}},
+			"source": {"rid": "b2c4d6e8-1a3b-4c5d-8e7f-9a0b1c2d3e4f", "rtype": "motion"},
+			"when": {
+				"timeslots": [
+					{
+						"start_time": {"type": "time", "time": {"hour": 7, "minute": 30}},
+						"on_motion": {"recall_single": [{"action": {"recall": {"rid": "c1d2e3f4-0000-4000-8000-000000000001", "rtype": "scene"}}}]},
+						"on_no_motion": {"after": {"minutes": 5}, "recall_single": [{"action": "all_off"}]}
+					},
+					{
+						"start_time": {"type": "sunset", "time": {"hour": 0, "minute": 0}, "offset": {"minutes": -30}},
+						"on_motion": {"recall_single": [{"action": {"recall": {"rid": "c1d2e3f4-0000-4000-8000-000000000002", "rtype": "scene"}}}]},
+						"on_no_motion": {"after": {"minutes": 2}, "recall_single": [{"action": "previous_state"}]}
+					}
+				]
+			},
+			"where": [{"group": {"rid": "d4e5f6a7-0000-4000-8000-000000000003", "rtype": "room"}}]
+		},
+		"metadata": {"name": "Hallway"}
+	}`
+	// Created in the Hue app with the lights left on when there is no more motion.
+	stairsMotionJSON = `{
+		"id": "7f1e2d3c-4b5a-4968-8776-655443322110",
+		"type": "behavior_instance",
+		"script_id": "5d8c7a2e-0b1a-4f47-9c43-8f0b0c3c9e10",
+		"enabled": false,
+		"configuration": {
+			"settings": {"daylight_sensitivity": {"dark_threshold": 20000, "offset": 7000}},
+			"source": {"rid": "e5f6a7b8-1a3b-4c5d-8e7f-9a0b1c2d3e4f", "rtype": "motion"},
+			"when": {
+				"timeslots": [
+					{
+						"start_time": {"type": "sunrise", "time": {"hour": 0, "minute": 0}},
+						"on_motion": {"recall_single": [{"action": {"recall": {"rid": "c1d2e3f4-0000-4000-8000-000000000004", "rtype": "scene"}}}]},
+						"on_no_motion": {"after": {"minutes": 10}}
+					}
+				]
+			},
+			"where": [{"group": {"rid": "f6a7b8c9-0000-4000-8000-000000000005", "rtype": "zone"}}]
+		},
+		"metadata": {"name": "Stairs"}
+	}`
+	// Set to a dim warm white at night without a scene.
+	landingMotionJSON = `{
+		"id": "9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+		"type": "behavior_instance",
+		"script_id": "5d8c7a2e-0b1a-4f47-9c43-8f0b0c3c9e10",
+		"enabled": true,
+		"configuration": {
+			"settings": {"daylight_sensitivity": {"dark_threshold": 15000, "offset": 7000}},
+			"sources": [
+				{"rid": "a1b2c3d4-1a3b-4c5d-8e7f-9a0b1c2d3e4f", "rtype": "motion"},
+				{"rid": "0c1d2e3f-0000-4000-8000-000000000007", "rtype": "grouped_motion"}
+			],
+			"when": {
+				"timeslots": [
+					{
+						"start_time": {"type": "time", "time": {"hour": 22, "minute": 0}},
+						"on_motion": {"recall_single": [{"action": {"light_state": {"dimming": {"brightness": 30}, "color_temperature": {"mirek": 454}, "dynamics": {"duration": 400}}}}]},
+						"on_no_motion": {"after": {"minutes": 1}, "recall_single": [{"action": "all_off"}]}
+					},
+					{
+						"start_time": {"type": "time", "time": {"hour": 6, "minute": 0}},
+						"on_motion": {"recall_single": [{"action": {"light_state": {"color": {"xy": {"x": 0.4573, "y": 0.41}}}}}]},
+						"on_no_motion": {"after": {"minutes": 3}, "recall_single": [{"action": "all_off"}]}
+					}
+				]
+			},
+			"where": [{"group": {"rid": "b2c3d4e5-0000-4000-8000-000000000006", "rtype": "zone"}}]
+		},
+		"metadata": {"name": "Landing"}
+	}`
+)
+
+func TestMotionAutomationMapping(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		model MotionAutomationResourceModel
+	}{
+		{
+			name: "fixed and solar time slots",
+			body: hallwayMotionJSON,
+			model: MotionAutomationResourceModel{
+				ID:             types.StringValue("4a9a6e3e-1f0c-4b43-9d64-2c1b4b0e8f11"),
+				Name:           types.StringValue("Hallway"),
+				Enabled:        types.BoolValue(true),
+				SensorID:       types.StringNull(),
+				Sources:        []Reference{{Id: types.StringValue("b2c4d6e8-1a3b-4c5d-8e7f-9a0b1c2d3e4f"), Type: types.StringValue("motion")}},
+				Targets:        []Reference{{Id: types.StringValue("d4e5f6a7-0000-4000-8000-000000000003"), Type: types.StringValue("room")}},
+				DarkThreshold:  types.Int32Value(12000),
+				DaylightOffset: types.Int32Value(5000),
+				TimeSlots: []TimeSlot{
+					{
+						Type:          types.StringValue("time"),
+						Hour:          types.Int32Value(7),
+						Minute:        types.Int32Value(30),
+						OffsetMinutes: types.Int32Null(),
+						Scenes:        []Reference{{Id: types.StringValue("c1d2e3f4-0000-4000-8000-000000000001"), Type: types.StringValue("scene")}},
+						AfterDelay:    types.Int32Value(5),
+						AfterState:    types.StringValue("all_off"),
+					},
+					{
+						Type:          types.StringValue("sunset"),
+						Hour:          types.Int32Null(),
+						Minute:        types.Int32Null(),
+						OffsetMinutes: types.Int32Value(-30),
+						Scenes:        []Reference{{Id: types.StringValue("c1d2e3f4-0000-4000-8000-000000000002"), Type: types.StringValue("scene")}},
+						AfterDelay:    types.Int32Value(2),
+						AfterState:    types.StringValue("previous_state"),
+					},
+				},
+			},
+		},
+		{
+			name: "disabled without a state after motion",
+			body: stairsMotionJSON,
+			model: MotionAutomationResourceModel{
+				ID:             types.StringValue("7f1e2d3c-4b5a-4968-8776-655443322110"),
+				Name:           types.StringValue("Stairs"),
+				Enabled:        types.BoolValue(false),
+				SensorID:       types.StringNull(),
+				Sources:        []Reference{{Id: types.StringValue("e5f6a7b8-1a3b-4c5d-8e7f-9a0b1c2d3e4f"), Type: types.StringValue("motion")}},
+				Targets:        []Reference{{Id: types.StringValue("f6a7b8c9-0000-4000-8000-000000000005"), Type: types.StringValue("zone")}},
+				DarkThreshold:  types.Int32Value(20000),
+				DaylightOffset: types.Int32Value(7000),
+				TimeSlots: []TimeSlot{
+					{
+						Type:          types.StringValue("sunrise"),
+						Hour:          types.Int32Null(),
+						Minute:        types.Int32Null(),
+						OffsetMinutes: types.Int32Null(),
+						Scenes:        []Reference{{Id: types.StringValue("c1d2e3f4-0000-4000-8000-000000000004"), Type: types.StringValue("scene")}},
+						AfterDelay:    types.Int32Value(10),
+						AfterState:    types.StringNull(),
+					},
+				},
+			},
+		},
+		{
+			name: "inline light settings",
+			body: landingMotionJSON,
+			model: MotionAutomationResourceModel{
+				ID:       types.StringValue("9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+				Name:     types.StringValue("Landing"),
+				Enabled:  types.BoolValue(true),
+				SensorID: types.StringNull(),
+				Sources: []Reference{
+					{Id: types.StringValue("a1b2c3d4-1a3b-4c5d-8e7f-9a0b1c2d3e4f"), Type: types.StringValue("motion")},
+					{Id: types.StringValue("0c1d2e3f-0000-4000-8000-000000000007"), Type: types.StringValue("grouped_motion")},
+				},
+				Targets:        []Reference{{Id: types.StringValue("b2c3d4e5-0000-4000-8000-000000000006"), Type: types.StringValue("zone")}},
+				DarkThreshold:  types.Int32Value(15000),
+				DaylightOffset: types.Int32Value(7000),
+				TimeSlots: []TimeSlot{
+					{
+						Type:          types.StringValue("time"),
+						Hour:          types.Int32Value(22),
+						Minute:        types.Int32Value(0),
+						OffsetMinutes: types.Int32Null(),
+						Light: &TimeSlotLightModel{
+							Brightness:         huetypes.NewBrightnessValue(30),
+							ColorTemperature:   huetypes.NewKelvinFromMirek(454),
+							TransitionDuration: types.Int64Value(400),
+						},
+						AfterDelay: types.Int32Value(1),
+						AfterState: types.StringValue("all_off"),
+					},
+					{
+						Type:          types.StringValue("time"),
+						Hour:          types.Int32Value(6),
+						Minute:        types.Int32Value(0),
+						OffsetMinutes: types.Int32Null(),
+						Light: &TimeSlotLightModel{
+							Brightness:         huetypes.NewBrightnessNull(),
+							ColorTemperature:   huetypes.NewKelvinNull(),
+							Color:              &TimeSlotColorModel{X: huetypes.NewXYValue(0.4573), Y: huetypes.NewXYValue(0.41)},
+							TransitionDuration: types.Int64Null(),
+						},
+						AfterDelay: types.Int32Value(3),
+						AfterState: types.StringValue("all_off"),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		var body behavior_instance.Data
+		if err := json.Unmarshal([]byte(tt.body), &body); err != nil {
+			t.Fatalf("%s: could not unmarshal bridge response: %v", tt.name, err)
+		}
+
+		t.Run(tt.name+"/read", func(t *testing.T) {
+			got := SetModelFromBody(body)
+			if !reflect.DeepEqual(*got, tt.model) {
+				t.Errorf("SetModelFromBody() = %+v, want %+v", *got, tt.model)
+			}
+		})
+
+		t.Run(tt.name+"/create", func(t *testing.T) {
+			got := SetCreateFromBody(tt.model, body.ScriptID)
+			if !reflect.DeepEqual(got.Configuration, body.Configuration) {
+				t.Errorf("SetCreateFromBody().Configuration = %+v, want %+v", got.Configuration, body.Configuration)
+			}
+			if got.ScriptID != body.ScriptID || got.Enabled != body.Enabled || got.Metadata == nil || *got.Metadata != body.Metadata {
+				t.Errorf("SetCreateFromBody() = %+v, want script %q, enabled %v and metadata %+v", got, body.ScriptID, body.Enabled, body.Metadata)
+			}
+		})
+
+		t.Run(tt.name+"/update", func(t *testing.T) {
+			got := SetUpdateFromBody(tt.model)
+			if got.Configuration == nil || !reflect.DeepEqual(*got.Configuration, body.Configuration) {
+				t.Errorf("SetUpdateFromBody().Configuration = %+v, want %+v", got.Configuration, body.Configuration)
+			}
+			if got.Enabled == nil || *got.Enabled != body.Enabled || got.Metadata == nil || *got.Metadata != body.Metadata {
+				t.Errorf("SetUpdateFromBody() = %+v, want enabled %v and metadata %+v", got, body.Enabled, body.Metadata)
+			}
+		})
+	}
+}
+
+func TestMotionAutomationLegacySensorID(t *testing.T) {
+	model := MotionAutomationResourceModel{SensorID: types.StringValue("b2c4d6e8-1a3b-4c5d-8e7f-9a0b1c2d3e4f")}
+	source, sources := createSources(model)
+	if source == nil || source.RID != "b2c4d6e8-1a3b-4c5d-8e7f-9a0b1c2d3e4f" || source.RType != "sensor" || sources != nil {
+		t.Fatalf("createSources() = %+v, %+v, want the sensor as the single source", source, sources)
+	}
+
+	var body behavior_instance.Data
+	if err := json.Unmarshal([]byte(hallwayMotionJSON), &body); err != nil {
+		t.Fatalf("could not unmarshal bridge response: %v", err)
+	}
+	got := keepLegacySensorID(model, SetModelFromBody(body))
+	if got.SensorID.ValueString() != "b2c4d6e8-1a3b-4c5d-8e7f-9a0b1c2d3e4f" || got.Sources != nil {
+		t.Errorf("keepLegacySensorID() = %v, %v, want sensor_id and no sources", got.SensorID, got.Sources)
+	}
+
+	got = keepLegacySensorID(MotionAutomationResourceModel{SensorID: types.StringNull()}, SetModelFromBody(body))
+	if !got.SensorID.IsNull() || len(got.Sources) != 1 {
+		t.Errorf("keepLegacySensorID() = %v, %v, want sources only", got.SensorID, got.Sources)
+	}
+}
+
+func TestMotionAutomationLegacyAfterState(t *testing.T) {
+	timeSlot := TimeSlot{AfterDelay: types.Int32Value(5), AfterState: types.StringValue("all_of")}
+	if got := createOnNoMotion(timeSlot).RecallSingle[0].Action; got != "all_off" {
+		t.Errorf("createOnNoMotion() action = %q, want the deprecated all_of sent as all_off", got)
+	}
+
+	var body behavior_instance.Data
+	if err := json.Unmarshal([]byte(hallwayMotionJSON), &body); err != nil {
+		t.Fatalf("could not unmarshal bridge response: %v", err)
+	}
+	prior := MotionAutomationResourceModel{TimeSlots: []TimeSlot{timeSlot}}
+	if got := keepLegacyAfterState(prior, SetModelFromBody(body)); got.TimeSlots[0].AfterState.ValueString() != "all_of" {
+		t.Errorf("keepLegacyAfterState() = %v, want the prior all_of kept", got.TimeSlots[0].AfterState)
+	}
+
+	prior.TimeSlots[0].AfterState = types.StringValue("previous_state")
+	if got := keepLegacyAfterState(prior, SetModelFromBody(body)); got.TimeSlots[0].AfterState.ValueString() != "all_off" {
+		t.Errorf("keepLegacyAfterState() = %v, want the bridge value all_off", got.TimeSlots[0].AfterState)
+	}
+}
@@ -2,17 +2,26 @@ package motion
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/light_level"
 	"github.com/richseviora/huego/pkg/resources/motion"
+	"github.com/richseviora/huego/pkg/resources/temperature"
 	"regexp"
 	"terraform-provider-philips/internal/provider/device"
 )
@@ -24,11 +33,21 @@ var (
 )
 
 type MotionResourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	Type      types.String `tfsdk:"type"`
-	DeviceID  types.String `tfsdk:"device_id"`
-	Reference types.Object `tfsdk:"reference"`
-	Enabled   types.Bool   `tfsdk:"enabled"`
+	Id         types.String `tfsdk:"id"`
+	Type       types.String `tfsdk:"type"`
+	DeviceID   types.String `tfsdk:"device_id"`
+	Reference  types.Object `tfsdk:"reference"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	MacAddress types.String `tfsdk:"mac_address"`
+	OnDestroy  types.String `tfsdk:"on_destroy"`
+	// The sensitivity of the motion sensor, up to the maximum supported by the sensor.
+	Sensitivity    types.Int32 `tfsdk:"sensitivity"`
+	SensitivityMax types.Int32 `tfsdk:"sensitivity_max"`
+	// The light level and temperature services of the same device.
+	LightLevelEnabled  types.Bool   `tfsdk:"light_level_enabled"`
+	LightLevelID       types.String `tfsdk:"light_level_id"`
+	TemperatureEnabled types.Bool   `tfsdk:"temperature_enabled"`
+	TemperatureID      types.String `tfsdk:"temperature_id"`
 }
 
 type MotionResource struct {
@@ -51,22 +70,21 @@ func (m *MotionResource) Configure(ctx context.Context, request resource.Configu
 }
 
 func (m *MotionResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	matched, err := regexp.MatchString(`^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$`, request.ID)
-	if err != nil {
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
-	} else if !matched {
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
-	}
-
-	id, err := m.client.GetMotionIDForMacAddress(request.ID)
-	if err != nil {
-		response.Diagnostics.AddError("Error importing motion sensor", "Could not find motion with MAC address "+request.ID+": "+err.Error())
-		return
+	id := request.ID
+	if macAddressRegex.MatchString(request.ID) {
+		motionID, err := m.client.GetMotionIDForMacAddress(request.ID)
+		if err != nil {
+			response.Diagnostics.AddError("Error importing motion sensor", "Could not find motion with MAC address "+request.ID+": "+err.Error())
+			return
+		}
+		id = motionID
 	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), resource.ImportStateRequest{
 		ID:                 id,
 		ClientCapabilities: request.ClientCapabilities,
 	}, response)
+	// An imported motion sensor gets the default on_destroy, so that it does not show as a change.
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("on_destroy"), "forget")...)
 }
 
 func (m *MotionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -92,6 +110,54 @@ func (m *MotionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:    true,
 				Description: "Whether the motion sensor is enabled.",
 			},
+			"sensitivity": schema.Int32Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The motion sensitivity, from 0 up to `sensitivity_max`. Read from the sensor when not set.",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"sensitivity_max": schema.Int32Attribute{
+				Computed:    true,
+				Description: "The highest motion sensitivity supported by the sensor.",
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"light_level_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the light level sensor of the device is enabled. Read from the sensor when not set.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"light_level_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the light level service of the device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"temperature_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the temperature sensor of the device is enabled. Read from the sensor when not set.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"temperature_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the temperature service of the device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"reference": schema.ObjectAttribute{
 				Computed:            true,
 				Description:         "The reference of the Motion in the Hue Bridge.",
@@ -111,13 +177,97 @@ func (m *MotionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"mac_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "The MAC address of the motion sensor. When set, creating the resource adopts the existing motion sensor with this MAC address instead of requiring an import.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(macAddressRegex, "must be a MAC address"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						// Adding or removing the MAC address of an imported motion sensor, or changing its format, must not replace it.
+						resp.RequiresReplace = !req.StateValue.IsNull() && !req.ConfigValue.IsNull() && !req.ConfigValue.IsUnknown() &&
+							device.NormalizeMacAddress(req.StateValue.ValueString()) != device.NormalizeMacAddress(req.ConfigValue.ValueString())
+					}, "Changing the MAC address adopts a different motion sensor.", "Changing the MAC address adopts a different motion sensor."),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("forget"),
+				Description: "What happens to the motion sensor when the resource is destroyed. `forget` only removes it from the Terraform state, `reset` re-enables the sensor, and `unpair` deletes the device from the bridge.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("forget", "reset", "unpair"),
+				},
+			},
 		},
 	}
 }
 
+var macAddressRegex = regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5,7}[0-9A-Fa-f]{2}$`)
+
 func (m *MotionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	resp.Diagnostics.AddError("Not implemented", "Direct create is not supported for this resource. Please import the resource instead.")
-	return
+	var data MotionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.MacAddress.IsNull() {
+		resp.Diagnostics.AddError("Not implemented", "Direct create is not supported for this resource without a mac_address. Please set mac_address or import the resource instead.")
+		return
+	}
+
+	id, err := m.client.GetMotionIDForMacAddress(data.MacAddress.ValueString())
+	if err == nil && id == "" {
+		err = errors.New("device with MAC address " + data.MacAddress.ValueString() + " is not a motion sensor")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting motion sensor",
+			"Could not find motion sensor with MAC address "+data.MacAddress.ValueString()+": "+err.Error())
+		return
+	}
+
+	current, err := m.client.MotionService().GetMotion(ctx, id)
+	if err == nil {
+		err = validateSensitivity(data, current)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting motion sensor",
+			"Could not adopt motion ID "+id+": "+err.Error(),
+		)
+		return
+	}
+	_, err = m.client.MotionService().UpdateMotion(ctx, id, motionUpdateRequest(data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting motion sensor",
+			"Could not update motion ID "+id+": "+err.Error(),
+		)
+		return
+	}
+
+	resource, err := m.client.MotionService().GetMotion(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting motion sensor",
+			"Could not read motion ID "+id+": "+err.Error())
+		return
+	}
+	if err := m.updateCompanionServices(ctx, data, resource.Owner.RID); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting motion sensor",
+			"Could not update the companion sensors of device ID "+resource.Owner.RID+": "+err.Error())
+		return
+	}
+	if err := m.setSensorState(ctx, &data, resource); err != nil {
+		resp.Diagnostics.AddError(
+			"Error adopting motion sensor",
+			"Could not read the companion sensors of device ID "+resource.Owner.RID+": "+err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (m *MotionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -126,15 +276,106 @@ func (m *MotionResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resource, err := m.client.MotionService().GetMotion(ctx, data.Id.ValueString())
+	if data.OnDestroy.IsNull() {
+		// Imported before on_destroy was set on import.
+		data.OnDestroy = types.StringValue("forget")
+	}
+	// A sensor that was removed or re-paired is not found, either as a motion service or as a device of the cache.
+	err := m.readSensor(ctx, &data, data.Id.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		newID := m.findRepairedMotionID(data)
+		if newID == "" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Motion sensor re-paired",
+			"Motion ID "+data.Id.ValueString()+" no longer exists, but MAC address "+data.MacAddress.ValueString()+" now belongs to motion ID "+newID+". The resource now tracks the new motion ID.")
+		err = m.readSensor(ctx, &data, newID)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading light",
+			"Error reading motion sensor",
 			"Could not read motion ID "+data.Id.ValueString()+": "+err.Error())
 		return
 	}
-	data.Id = types.StringValue(resource.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readSensor sets the state of the motion service with the given ID and the other services of its device.
+func (m *MotionResource) readSensor(ctx context.Context, data *MotionResourceModel, id string) error {
+	resource, err := m.client.MotionService().GetMotion(ctx, id)
+	if err != nil {
+		return err
+	}
 	data.Enabled = types.BoolValue(resource.Enabled)
+	return m.setSensorState(ctx, data, resource)
+}
+
+// motionUpdateRequest maps the settings of the motion service. The sensitivity is only sent when it is managed.
+func motionUpdateRequest(data MotionResourceModel) motion.UpdateRequest {
+	update := motion.UpdateRequest{
+		Enabled: data.Enabled.ValueBool(),
+	}
+	if !data.Sensitivity.IsNull() && !data.Sensitivity.IsUnknown() {
+		update.Sensitivity = &motion.Sensitivity{Sensitivity: int(data.Sensitivity.ValueInt32())}
+	}
+	return update
+}
+
+// validateSensitivity checks that a managed sensitivity is supported by the sensor.
+func validateSensitivity(data MotionResourceModel, resource *motion.Data) error {
+	if data.Sensitivity.IsNull() || data.Sensitivity.IsUnknown() {
+		return nil
+	}
+	if resource.Sensitivity == nil {
+		return errors.New("the sensor does not support setting the sensitivity")
+	}
+	if int(data.Sensitivity.ValueInt32()) > resource.Sensitivity.SensitivityMax {
+		return fmt.Errorf("the sensitivity %d exceeds the maximum sensitivity %d of the sensor", data.Sensitivity.ValueInt32(), resource.Sensitivity.SensitivityMax)
+	}
+	return nil
+}
+
+// updateCompanionServices enables or disables the light level and temperature services of the device when they are managed.
+func (m *MotionResource) updateCompanionServices(ctx context.Context, data MotionResourceModel, deviceID string) error {
+	manageLightLevel := !data.LightLevelEnabled.IsNull() && !data.LightLevelEnabled.IsUnknown()
+	manageTemperature := !data.TemperatureEnabled.IsNull() && !data.TemperatureEnabled.IsUnknown()
+	if !manageLightLevel && !manageTemperature {
+		return nil
+	}
+	entry, err := m.client.GetDeviceMappingEntry(deviceID)
+	if err != nil {
+		return err
+	}
+	if manageLightLevel {
+		if entry.LightLevelID == "" {
+			return errors.New("the device has no light level sensor")
+		}
+		_, err := m.client.LightLevelService().UpdateLightLevel(ctx, entry.LightLevelID, light_level.UpdateRequest{
+			Enabled: data.LightLevelEnabled.ValueBool(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if manageTemperature {
+		if entry.TemperatureID == "" {
+			return errors.New("the device has no temperature sensor")
+		}
+		_, err := m.client.TemperatureService().UpdateTemperature(ctx, entry.TemperatureID, temperature.UpdateRequest{
+			Enabled: data.TemperatureEnabled.ValueBool(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSensorState sets the computed attributes from the motion service and the other services of its device.
+func (m *MotionResource) setSensorState(ctx context.Context, data *MotionResourceModel, resource *motion.Data) error {
+	data.Id = types.StringValue(resource.ID)
 	data.Reference, _ = types.ObjectValue(map[string]attr.Type{
 		"rid":   types.StringType,
 		"rtype": types.StringType,
@@ -143,7 +384,48 @@ func (m *MotionResource) Read(ctx context.Context, req resource.ReadRequest, res
 		"rtype": types.StringValue("motion"),
 	})
 	data.DeviceID = types.StringValue(resource.Owner.RID)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.Sensitivity, data.SensitivityMax = types.Int32Null(), types.Int32Null()
+	if resource.Sensitivity != nil {
+		data.Sensitivity = types.Int32Value(int32(resource.Sensitivity.Sensitivity))
+		data.SensitivityMax = types.Int32Value(int32(resource.Sensitivity.SensitivityMax))
+	}
+
+	entry, err := m.client.GetDeviceMappingEntry(resource.Owner.RID)
+	if err != nil {
+		return err
+	}
+	data.LightLevelID, data.LightLevelEnabled = types.StringNull(), types.BoolNull()
+	if entry.LightLevelID != "" {
+		lightLevel, err := m.client.LightLevelService().GetLightLevel(ctx, entry.LightLevelID)
+		if err != nil {
+			return err
+		}
+		data.LightLevelID = types.StringValue(lightLevel.ID)
+		data.LightLevelEnabled = types.BoolValue(lightLevel.Enabled)
+	}
+	data.TemperatureID, data.TemperatureEnabled = types.StringNull(), types.BoolNull()
+	if entry.TemperatureID != "" {
+		temperatureData, err := m.client.TemperatureService().GetTemperature(ctx, entry.TemperatureID)
+		if err != nil {
+			return err
+		}
+		data.TemperatureID = types.StringValue(temperatureData.ID)
+		data.TemperatureEnabled = types.BoolValue(temperatureData.Enabled)
+	}
+	return nil
+}
+
+// findRepairedMotionID returns the current motion ID for the MAC address of the sensor,
+// or an empty string when the sensor cannot be found under a different ID.
+func (m *MotionResource) findRepairedMotionID(data MotionResourceModel) string {
+	if data.MacAddress.IsNull() || data.MacAddress.IsUnknown() {
+		return ""
+	}
+	id, err := m.client.GetMotionIDForMacAddress(data.MacAddress.ValueString())
+	if err != nil || id == data.Id.ValueString() {
+		return ""
+	}
+	return id
 }
 
 func (m *MotionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -152,10 +434,18 @@ func (m *MotionResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	update := &motion.UpdateRequest{
-		Enabled: data.Enabled.ValueBool(),
+	current, err := m.client.MotionService().GetMotion(ctx, data.Id.ValueString())
+	if err == nil {
+		err = validateSensitivity(data, current)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating motion",
+			"Could not update motion ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
 	}
-	_, err := m.client.MotionService().UpdateMotion(ctx, data.Id.ValueString(), *update)
+	_, err = m.client.MotionService().UpdateMotion(ctx, data.Id.ValueString(), motionUpdateRequest(data))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating motion",
@@ -163,12 +453,58 @@ func (m *MotionResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
+	if err := m.updateCompanionServices(ctx, data, data.DeviceID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating motion",
+			"Could not update the companion sensors of device ID "+data.DeviceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resource, err := m.client.MotionService().GetMotion(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating motion",
+			"Could not read motion ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err := m.setSensorState(ctx, &data, resource); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating motion",
+			"Could not read the companion sensors of device ID "+data.DeviceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (m *MotionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	resp.Diagnostics.AddError("Not implemented", "Direct delete is not supported for this resource. Please remove the resource from the app instead.")
-	return
+	var data MotionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch data.OnDestroy.ValueString() {
+	case "reset":
+		_, err := m.client.MotionService().UpdateMotion(ctx, data.Id.ValueString(), motion.UpdateRequest{
+			Enabled: true,
+		})
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Error resetting motion sensor",
+				"Could not reset motion ID "+data.Id.ValueString()+": "+err.Error(),
+			)
+		}
+	case "unpair":
+		err := m.client.DeviceService().DeleteDevice(ctx, data.DeviceID.ValueString())
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Error unpairing motion sensor",
+				"Could not delete device ID "+data.DeviceID.ValueString()+": "+err.Error(),
+			)
+		}
+	}
 }
 
 func NewMotionResource() resource.Resource {
@@ -0,0 +1,220 @@
+package motion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/motion"
+	"terraform-provider-philips/internal/provider/device"
+)
+
+func TestValidateSensitivity(t *testing.T) {
+	supported := &motion.Data{Sensitivity: &motion.Sensitivity{Sensitivity: 2, SensitivityMax: 4}}
+	tests := []struct {
+		name        string
+		sensitivity types.Int32
+		resource    *motion.Data
+		wantErr     bool
+	}{
+		{name: "not managed", sensitivity: types.Int32Null(), resource: &motion.Data{}},
+		{name: "within range", sensitivity: types.Int32Value(4), resource: supported},
+		{name: "above maximum", sensitivity: types.Int32Value(5), resource: supported, wantErr: true},
+		{name: "not supported", sensitivity: types.Int32Value(1), resource: &motion.Data{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSensitivity(MotionResourceModel{Sensitivity: tt.sensitivity}, tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSensitivity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMotionUpdateRequest(t *testing.T) {
+	update := motionUpdateRequest(MotionResourceModel{Enabled: types.BoolValue(true), Sensitivity: types.Int32Unknown()})
+	if !update.Enabled || update.Sensitivity != nil {
+		t.Errorf("expected an enabled update without sensitivity, got %+v", update)
+	}
+	update = motionUpdateRequest(MotionResourceModel{Enabled: types.BoolValue(false), Sensitivity: types.Int32Value(3)})
+	if update.Enabled || update.Sensitivity == nil || update.Sensitivity.Sensitivity != 3 {
+		t.Errorf("expected a disabled update with sensitivity 3, got %+v", update)
+	}
+}
+
+// motionClient is a client that only serves motion services, the devices of the cache and their MAC addresses.
+type motionClient struct {
+	device.ClientWithLightIDCache
+	motions map[string]*motion.Data
+	devices map[string]device.DeviceMappingEntry
+	macs    map[string]string
+}
+
+func (c *motionClient) MotionService() motion.Service {
+	return c
+}
+
+func (c *motionClient) GetMotion(_ context.Context, id string) (*motion.Data, error) {
+	if resource, ok := c.motions[id]; ok {
+		return resource, nil
+	}
+	return nil, client.ErrNotFound
+}
+
+func (c *motionClient) UpdateMotion(_ context.Context, id string, _ motion.UpdateRequest) (*common.Reference, error) {
+	return &common.Reference{RID: id, RType: "motion"}, nil
+}
+
+func (c *motionClient) GetDeviceMappingEntry(deviceID string) (device.DeviceMappingEntry, error) {
+	if entry, ok := c.devices[deviceID]; ok {
+		return entry, nil
+	}
+	return device.DeviceMappingEntry{}, client.ErrNotFound
+}
+
+func (c *motionClient) GetMotionIDForMacAddress(macAddress string) (string, error) {
+	if id, ok := c.macs[macAddress]; ok {
+		return id, nil
+	}
+	return "", client.ErrNotFound
+}
+
+func TestMotionResourceRead(t *testing.T) {
+	const mac = "00:17:88:01:0b:aa:bb:cc"
+	repaired := &motion.Data{ID: "motion-2", Enabled: true, Owner: common.Reference{RID: "device-2", RType: "device"}}
+	tests := []struct {
+		name        string
+		client      *motionClient
+		wantRemoved bool
+		wantID      string
+		wantWarns   int
+	}{
+		{
+			name: "sensor present",
+			client: &motionClient{
+				motions: map[string]*motion.Data{"motion-1": {ID: "motion-1", Owner: common.Reference{RID: "device-1", RType: "device"}}},
+				devices: map[string]device.DeviceMappingEntry{"device-1": {DeviceID: "device-1"}},
+			},
+			wantID: "motion-1",
+		},
+		{
+			name:        "sensor removed",
+			client:      &motionClient{},
+			wantRemoved: true,
+		},
+		{
+			name: "device missing from the cache",
+			client: &motionClient{
+				motions: map[string]*motion.Data{"motion-1": {ID: "motion-1", Owner: common.Reference{RID: "device-1", RType: "device"}}},
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "sensor re-paired",
+			client: &motionClient{
+				motions: map[string]*motion.Data{
+					"motion-1": {ID: "motion-1", Owner: common.Reference{RID: "device-1", RType: "device"}},
+					"motion-2": repaired,
+				},
+				devices: map[string]device.DeviceMappingEntry{"device-2": {DeviceID: "device-2"}},
+				macs:    map[string]string{mac: "motion-2"},
+			},
+			wantID:    "motion-2",
+			wantWarns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := &MotionResource{client: tt.client}
+			schemaResp := &resource.SchemaResponse{}
+			m.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			diags := state.Set(ctx, &MotionResourceModel{
+				Id:                 types.StringValue("motion-1"),
+				Type:               types.StringValue("motion"),
+				DeviceID:           types.StringValue("device-1"),
+				Reference:          types.ObjectNull(map[string]attr.Type{"rid": types.StringType, "rtype": types.StringType}),
+				Enabled:            types.BoolValue(false),
+				MacAddress:         types.StringValue(mac),
+				OnDestroy:          types.StringNull(),
+				Sensitivity:        types.Int32Null(),
+				SensitivityMax:     types.Int32Null(),
+				LightLevelEnabled:  types.BoolNull(),
+				LightLevelID:       types.StringNull(),
+				TemperatureEnabled: types.BoolNull(),
+				TemperatureID:      types.StringNull(),
+			})
+			if diags.HasError() {
+				t.Fatalf("could not set the prior state: %v", diags)
+			}
+
+			resp := &resource.ReadResponse{State: state}
+			m.Read(ctx, resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read() errors = %v", resp.Diagnostics)
+			}
+			if resp.Diagnostics.WarningsCount() != tt.wantWarns {
+				t.Errorf("Read() warnings = %v, want %d", resp.Diagnostics, tt.wantWarns)
+			}
+			if resp.State.Raw.IsNull() != tt.wantRemoved {
+				t.Fatalf("Read() removed the resource = %v, want %v", resp.State.Raw.IsNull(), tt.wantRemoved)
+			}
+			if tt.wantRemoved {
+				return
+			}
+			var id types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			if id.ValueString() != tt.wantID {
+				t.Errorf("Read() id = %s, want %s", id.ValueString(), tt.wantID)
+			}
+			var onDestroy types.String
+			resp.State.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)
+			if onDestroy.ValueString() != "forget" {
+				t.Errorf("Read() on_destroy = %s, want forget", onDestroy)
+			}
+		})
+	}
+}
+
+func TestMotionResourceImportState(t *testing.T) {
+	const mac = "00:17:88:01:0B:AA:BB:CC"
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "motion ID", id: "motion-1"},
+		{name: "MAC address", id: mac},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := &MotionResource{client: &motionClient{macs: map[string]string{mac: "motion-1"}}}
+			schemaResp := &resource.SchemaResponse{}
+			m.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			resp := &resource.ImportStateResponse{State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}}
+			m.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ImportState() errors = %v", resp.Diagnostics)
+			}
+			var id, onDestroy, macAddress types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)
+			resp.State.GetAttribute(ctx, path.Root("mac_address"), &macAddress)
+			if id.ValueString() != "motion-1" || onDestroy.ValueString() != "forget" || !macAddress.IsNull() {
+				t.Errorf("ImportState() id = %s, on_destroy = %s, mac_address = %s", id, onDestroy, macAddress)
+			}
+		})
+	}
+}
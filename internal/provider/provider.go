@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/richseviora/huego/pkg"
 	"github.com/richseviora/huego/pkg/resources/client"
+	"terraform-provider-philips/internal/provider/behavior"
 	"terraform-provider-philips/internal/provider/device"
 	"terraform-provider-philips/internal/provider/logger"
 	"terraform-provider-philips/internal/provider/motion"
@@ -129,11 +130,17 @@ func (p *PhilipsHueProvider) Configure(ctx context.Context, req provider.Configu
 func (p *PhilipsHueProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewLightResource,
+		behavior.NewBehaviorInstanceResource,
 		NewRoomResource,
 		NewSceneResource,
+		NewSceneActivationResource,
+		NewSmartSceneResource,
 		NewZoneResource,
 		motion.NewMotionResource,
 		motion.NewMotionAutomationResource,
+		behavior.NewWakeUpAutomationResource,
+		behavior.NewGoToSleepAutomationResource,
+		behavior.NewTimerAutomationResource,
 	}
 }
 
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/scene"
+	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+var _ resource.Resource = &SceneActivationResource{}
+var _ resource.ResourceWithConfigure = &SceneActivationResource{}
+
+func NewSceneActivationResource() resource.Resource {
+	return &SceneActivationResource{}
+}
+
+// SceneActivationResource recalls a scene when it is created and whenever it is updated. It does not
+// own any bridge state, so destroying it only removes it from the Terraform state.
+type SceneActivationResource struct {
+	client device.ClientWithLightIDCache
+}
+
+type SceneActivationResourceModel struct {
+	Id         types.String        `tfsdk:"id"`
+	SceneID    types.String        `tfsdk:"scene_id"`
+	Action     types.String        `tfsdk:"action"`
+	Duration   types.Int64         `tfsdk:"duration"`
+	Brightness huetypes.Brightness `tfsdk:"brightness"`
+	Triggers   types.Map           `tfsdk:"triggers"`
+}
+
+func (s *SceneActivationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scene_activation"
+}
+
+func (s *SceneActivationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Recalls a Philips Hue scene when it is created, and again whenever any of its attributes, such as `triggers`, change. Destroying it does not change any lights.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scene_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the scene to recall. Changing it replaces the activation, which recalls the new scene.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("active"),
+				Description: "How the scene is recalled: `active`, `dynamic_palette` or `static`. Defaults to `active`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("active", "dynamic_palette", "static"),
+				},
+			},
+			"duration": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The transition duration in milliseconds.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"brightness": schema.Float64Attribute{
+				Optional:    true,
+				CustomType:  huetypes.BrightnessType{},
+				Description: "The brightness from 0 to 100 that overrides the brightness of the scene actions.",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 100),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that recall the scene again when they change, for example the ID of a scene that was replaced.",
+			},
+		},
+	}
+}
+
+func (s *SceneActivationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(device.ClientWithLightIDCache)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected device.ClientWithLightIDCache, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	s.client = client
+}
+
+func (s *SceneActivationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SceneActivationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := s.recallScene(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error recalling scene",
+			"Could not recall scene ID "+data.SceneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	data.Id = data.SceneID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *SceneActivationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SceneActivationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The recall itself leaves nothing to read back, only the scene has to still exist.
+	_, err := s.client.SceneService().GetScene(ctx, data.SceneID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading scene",
+			"Could not read scene ID "+data.SceneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *SceneActivationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SceneActivationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := s.recallScene(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error recalling scene",
+			"Could not recall scene ID "+data.SceneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *SceneActivationResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Recalling a scene cannot be undone, the lights keep their current state.
+}
+
+func (s *SceneActivationResource) recallScene(ctx context.Context, data SceneActivationResourceModel) error {
+	return s.client.SceneService().RecallScene(ctx, data.SceneID.ValueString(), createSceneRecallObj(data))
+}
+
+func createSceneRecallObj(data SceneActivationResourceModel) scene.Recall {
+	recall := scene.Recall{
+		Action: data.Action.ValueString(),
+	}
+	// Without a duration, the bridge uses the transition of the scene.
+	if !data.Duration.IsNull() {
+		recall.Duration = int(data.Duration.ValueInt64())
+	}
+	if !data.Brightness.IsNull() {
+		recall.Dimming = &common.Dimming{Brightness: data.Brightness.ValueFloat64()}
+	}
+	return recall
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/scene"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+func TestCreateSceneRecallObj(t *testing.T) {
+	tests := []struct {
+		name string
+		data SceneActivationResourceModel
+		want scene.Recall
+	}{
+		{
+			name: "defaults",
+			data: SceneActivationResourceModel{
+				SceneID:    types.StringValue("scene-id"),
+				Action:     types.StringValue("active"),
+				Duration:   types.Int64Null(),
+				Brightness: huetypes.NewBrightnessNull(),
+			},
+			want: scene.Recall{Action: "active"},
+		},
+		{
+			name: "dynamic with transition and brightness",
+			data: SceneActivationResourceModel{
+				SceneID:    types.StringValue("scene-id"),
+				Action:     types.StringValue("dynamic_palette"),
+				Duration:   types.Int64Value(2000),
+				Brightness: huetypes.NewBrightnessValue(40),
+			},
+			want: scene.Recall{Action: "dynamic_palette", Duration: 2000, Dimming: &common.Dimming{Brightness: 40}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createSceneRecallObj(tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("createSceneRecallObj() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"maps"
+)
+
+// sceneGroupLightIDs returns the IDs of the lights in the room or zone of a scene. It returns nil if the
+// group does not exist, or is not a room or zone.
+func (s *SceneResource) sceneGroupLightIDs(ctx context.Context, group *ResourceReference) ([]string, error) {
+	var children []common.Reference
+	var err error
+	switch group.Rtype.ValueString() {
+	case "room":
+		room, roomErr := s.client.RoomService().GetRoom(ctx, group.Rid.ValueString())
+		if roomErr == nil {
+			children = room.Children
+		}
+		err = roomErr
+	case "zone":
+		zone, zoneErr := s.client.ZoneService().GetZone(ctx, group.Rid.ValueString())
+		if zoneErr == nil {
+			children = zone.Children
+		}
+		err = zoneErr
+	default:
+		return nil, nil
+	}
+	if errors.Is(err, client.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Rooms hold devices and zones hold lights, a device is resolved to the lights it provides.
+	lightIDs := []string{}
+	for _, child := range children {
+		switch child.RType {
+		case "light":
+			lightIDs = append(lightIDs, child.RID)
+		case "device":
+			d, err := s.client.DeviceService().GetDevice(ctx, child.RID)
+			if errors.Is(err, client.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, service := range d.Services {
+				if service.Rtype == "light" {
+					lightIDs = append(lightIDs, service.Rid)
+				}
+			}
+		}
+	}
+	return lightIDs, nil
+}
+
+// defaultActionTargets returns the lights of the scene's group that have no action and receive the
+// default action instead. It is null when the scene has no default action.
+func defaultActionTargets(ctx context.Context, data SceneResourceModel, groupLightIDs []string) types.Set {
+	if data.DefaultAction == nil {
+		return types.SetNull(types.StringType)
+	}
+	targets := []string{}
+	for _, id := range groupLightIDs {
+		if _, ok := data.Actions[id]; !ok {
+			targets = append(targets, id)
+		}
+	}
+	result, _ := types.SetValueFrom(ctx, types.StringType, targets)
+	return result
+}
+
+// withDefaultActions returns the actions to send to the bridge, including the default action for every
+// light in the default action targets.
+func withDefaultActions(ctx context.Context, data SceneResourceModel) map[string]SceneActionModel {
+	actions := maps.Clone(data.Actions)
+	if data.DefaultAction == nil || data.DefaultActionTargets.IsNull() || data.DefaultActionTargets.IsUnknown() {
+		return actions
+	}
+	var targets []string
+	data.DefaultActionTargets.ElementsAs(ctx, &targets, false)
+	for _, id := range targets {
+		if _, ok := actions[id]; !ok {
+			actions[id] = *data.DefaultAction
+		}
+	}
+	return actions
+}
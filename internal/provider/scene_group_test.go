@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+func TestDefaultActions(t *testing.T) {
+	ctx := context.Background()
+	configured := SceneActionModel{TargetType: types.StringValue("light"), Brightness: huetypes.NewBrightnessValue(100)}
+	fallback := SceneActionModel{TargetType: types.StringValue("light"), Brightness: huetypes.NewBrightnessValue(10)}
+	data := SceneResourceModel{
+		Actions: map[string]SceneActionModel{"lamp": configured},
+	}
+
+	if targets := defaultActionTargets(ctx, data, []string{"lamp", "ceiling"}); !targets.IsNull() {
+		t.Errorf("expected no default action targets without a default action, got %v", targets)
+	}
+	if actions := withDefaultActions(ctx, data); len(actions) != 1 {
+		t.Errorf("expected only the configured action without a default action, got %v", actions)
+	}
+
+	data.DefaultAction = &fallback
+	data.DefaultActionTargets = defaultActionTargets(ctx, data, []string{"lamp", "ceiling", "strip"})
+	var targets []string
+	data.DefaultActionTargets.ElementsAs(ctx, &targets, false)
+	if len(targets) != 2 {
+		t.Fatalf("expected the lights without an action to be default action targets, got %v", targets)
+	}
+
+	actions := withDefaultActions(ctx, data)
+	if len(actions) != 3 {
+		t.Fatalf("expected 3 actions, got %v", actions)
+	}
+	if !actions["lamp"].Brightness.Equal(configured.Brightness) {
+		t.Errorf("expected the configured action to take precedence, got %v", actions["lamp"])
+	}
+	if !actions["ceiling"].Brightness.Equal(fallback.Brightness) || !actions["strip"].Brightness.Equal(fallback.Brightness) {
+		t.Errorf("expected the default action for lights without an action, got %v", actions)
+	}
+	if len(data.Actions) != 1 {
+		t.Errorf("expected the configured actions to be left unchanged, got %v", data.Actions)
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/richseviora/huego/pkg/resources/color"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/light"
+	"github.com/richseviora/huego/pkg/resources/scene"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+type ScenePaletteColorModel struct {
+	X          huetypes.XY         `tfsdk:"x"`
+	Y          huetypes.XY         `tfsdk:"y"`
+	Brightness huetypes.Brightness `tfsdk:"brightness"`
+}
+
+type ScenePaletteColorTemperatureModel struct {
+	ColorTemperature huetypes.Kelvin     `tfsdk:"color_temperature"`
+	Brightness       huetypes.Brightness `tfsdk:"brightness"`
+}
+
+// ScenePaletteModel describes the colors a dynamic scene cycles through.
+type ScenePaletteModel struct {
+	Colors            []ScenePaletteColorModel            `tfsdk:"colors"`
+	ColorTemperatures []ScenePaletteColorTemperatureModel `tfsdk:"color_temperatures"`
+	Dimming           []huetypes.Brightness               `tfsdk:"dimming"`
+}
+
+func scenePaletteSchema() schema.SingleNestedAttribute {
+	brightness := schema.Float64Attribute{
+		Required:    true,
+		CustomType:  huetypes.BrightnessType{},
+		Description: "The brightness of the palette entry from 0 to 100.",
+		Validators: []validator.Float64{
+			float64validator.Between(0, 100),
+		},
+	}
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "The palette a dynamic scene cycles through when it is recalled as a dynamic palette.",
+		Attributes: map[string]schema.Attribute{
+			"colors": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The xy colors of the palette, with their brightness.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(9),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"x": schema.Float64Attribute{
+							Required:    true,
+							CustomType:  huetypes.XYType{},
+							Description: "The x value of the palette color.",
+							Validators: []validator.Float64{
+								float64validator.Between(0, 1),
+							},
+						},
+						"y": schema.Float64Attribute{
+							Required:    true,
+							CustomType:  huetypes.XYType{},
+							Description: "The y value of the palette color.",
+							Validators: []validator.Float64{
+								float64validator.Between(0, 1),
+							},
+						},
+						"brightness": brightness,
+					},
+				},
+			},
+			"color_temperatures": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "The color temperatures of the palette, with their brightness.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"color_temperature": schema.Int32Attribute{
+							Required:    true,
+							CustomType:  huetypes.KelvinType{},
+							Description: "The color temperature of the palette entry from 2000 K to 6500 K.",
+							Validators: []validator.Int32{
+								int32validator.Between(2000, 6500),
+							},
+						},
+						"brightness": brightness,
+					},
+				},
+			},
+			"dimming": schema.ListAttribute{
+				Optional:    true,
+				ElementType: huetypes.BrightnessType{},
+				Description: "The brightness levels of the palette from 0 to 100.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+					listvalidator.ValueFloat64sAre(float64validator.Between(0, 100)),
+				},
+			},
+		},
+	}
+}
+
+func createScenePaletteObj(p *ScenePaletteModel) *scene.Palette {
+	if p == nil {
+		return nil
+	}
+	result := &scene.Palette{
+		Color:            make([]scene.PaletteColor, len(p.Colors)),
+		Dimming:          make([]common.Dimming, len(p.Dimming)),
+		ColorTemperature: make([]scene.PaletteColorTemperature, len(p.ColorTemperatures)),
+	}
+	for i, c := range p.Colors {
+		result.Color[i] = scene.PaletteColor{
+			Color: light.Color{
+				XY: color.XYCoord{
+					X: c.X.ValueFloat64(),
+					Y: c.Y.ValueFloat64(),
+				},
+			},
+			Dimming: common.Dimming{Brightness: c.Brightness.ValueFloat64()},
+		}
+	}
+	for i, d := range p.Dimming {
+		result.Dimming[i] = common.Dimming{Brightness: d.ValueFloat64()}
+	}
+	for i, ct := range p.ColorTemperatures {
+		result.ColorTemperature[i] = scene.PaletteColorTemperature{
+			ColorTemperature: light.ColorTemperature{
+				Mirek: ct.ColorTemperature.Mirek(),
+			},
+			Dimming: common.Dimming{Brightness: ct.Brightness.ValueFloat64()},
+		}
+	}
+	return result
+}
+
+func createScenePaletteModel(p *scene.Palette) *ScenePaletteModel {
+	if p == nil {
+		return nil
+	}
+	result := &ScenePaletteModel{}
+	if len(p.Color) > 0 {
+		result.Colors = make([]ScenePaletteColorModel, len(p.Color))
+		for i, c := range p.Color {
+			result.Colors[i] = ScenePaletteColorModel{
+				X:          huetypes.NewXYValue(c.Color.XY.X),
+				Y:          huetypes.NewXYValue(c.Color.XY.Y),
+				Brightness: huetypes.NewBrightnessValue(c.Dimming.Brightness),
+			}
+		}
+	}
+	if len(p.Dimming) > 0 {
+		result.Dimming = make([]huetypes.Brightness, len(p.Dimming))
+		for i, d := range p.Dimming {
+			result.Dimming[i] = huetypes.NewBrightnessValue(d.Brightness)
+		}
+	}
+	if len(p.ColorTemperature) > 0 {
+		result.ColorTemperatures = make([]ScenePaletteColorTemperatureModel, len(p.ColorTemperature))
+		for i, ct := range p.ColorTemperature {
+			result.ColorTemperatures[i] = ScenePaletteColorTemperatureModel{
+				ColorTemperature: huetypes.NewKelvinFromMirek(ct.ColorTemperature.Mirek),
+				Brightness:       huetypes.NewBrightnessValue(ct.Dimming.Brightness),
+			}
+		}
+	}
+	return result
+}
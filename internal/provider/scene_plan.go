@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/color"
+	"github.com/richseviora/huego/pkg/resources/light"
+	"math"
+	"slices"
+	"strings"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+var _ resource.ResourceWithModifyPlan = &SceneResource{}
+
+// sceneLightLookup fetches the target lights of a scene once per plan.
+type sceneLightLookup struct {
+	service light.LightService
+	lights  map[string]*light.Light
+}
+
+func newSceneLightLookup(service light.LightService) *sceneLightLookup {
+	return &sceneLightLookup{
+		service: service,
+		lights:  map[string]*light.Light{},
+	}
+}
+
+// get returns the light for the ID, or nil if the light does not exist.
+func (l *sceneLightLookup) get(ctx context.Context, id string) (*light.Light, error) {
+	if result, ok := l.lights[id]; ok {
+		return result, nil
+	}
+	result, err := l.service.GetLight(ctx, id)
+	if errors.Is(err, client.ErrNotFound) {
+		result, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l.lights[id] = result
+	return result, nil
+}
+
+func (s *SceneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || s.client == nil {
+		return
+	}
+	var data SceneResourceModel
+	if diags := req.Plan.Get(ctx, &data); diags.HasError() {
+		// Values that are unknown until apply cannot be validated at plan time.
+		return
+	}
+
+	// The lights of a group that is created in the same apply are only known once it exists.
+	var groupLightIDs []string
+	groupKnown := data.Group != nil && !data.Group.Rid.IsUnknown() && !data.Group.Rtype.IsUnknown()
+	if groupKnown {
+		var err error
+		groupLightIDs, err = s.sceneGroupLightIDs(ctx, data.Group)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading scene group",
+				"Could not read "+data.Group.Rtype.ValueString()+" ID "+data.Group.Rid.ValueString()+": "+err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("default_action_targets"),
+			defaultActionTargets(ctx, data, groupLightIDs))...)
+	}
+
+	lights := newSceneLightLookup(s.client.LightService())
+	validate := func(actionPath path.Path, targetID string, action SceneActionModel) bool {
+		target, err := lights.get(ctx, targetID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading scene target",
+				"Could not read light ID "+targetID+": "+err.Error())
+			return false
+		}
+		if target == nil {
+			return true
+		}
+		resp.Diagnostics.Append(validateSceneActionGradient(actionPath, action, target)...)
+		resp.Diagnostics.Append(validateSceneActionEffects(actionPath, action, target)...)
+		resp.Diagnostics.Append(validateSceneActionColor(actionPath, action, target, data.ClampToCapabilities.ValueBool())...)
+		return true
+	}
+
+	for targetID, action := range data.Actions {
+		if action.TargetType.ValueString() != "light" {
+			continue
+		}
+		actionPath := path.Root("actions").AtMapKey(targetID)
+		if groupLightIDs != nil && !slices.Contains(groupLightIDs, targetID) {
+			resp.Diagnostics.AddAttributeError(actionPath, "Scene Target Not In Group",
+				fmt.Sprintf("Light ID %s is not part of %s %s. Scene actions can only target the lights of the scene's group.",
+					targetID, data.Group.Rtype.ValueString(), data.Group.Rid.ValueString()))
+			continue
+		}
+		if !validate(actionPath, targetID, action) {
+			return
+		}
+	}
+
+	for _, id := range groupLightIDs {
+		if _, ok := data.Actions[id]; ok {
+			continue
+		}
+		if data.DefaultAction != nil {
+			if !validate(path.Root("default_action"), id, *data.DefaultAction) {
+				return
+			}
+			continue
+		}
+		target, err := lights.get(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading scene target",
+				"Could not read light ID "+id+": "+err.Error())
+			return
+		}
+		name := id
+		if target != nil {
+			name = fmt.Sprintf("%q (%s)", target.Metadata.Name, id)
+		}
+		resp.Diagnostics.AddAttributeWarning(path.Root("actions"), "Scene Light Without Action",
+			fmt.Sprintf("Light %s of %s %s has no action in this scene, so recalling the scene leaves it unchanged. Add an action for it, or set default_action.",
+				name, data.Group.Rtype.ValueString(), data.Group.Rid.ValueString()))
+	}
+}
+
+// validateSceneActionGradient checks the gradient of an action against the number of gradient points the light supports.
+func validateSceneActionGradient(actionPath path.Path, action SceneActionModel, target *light.Light) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if action.Gradient == nil {
+		return diags
+	}
+	if target.Gradient == nil || target.Gradient.PointsCapable == 0 {
+		diags.AddAttributeError(actionPath.AtName("gradient"), "Unsupported Scene Action",
+			fmt.Sprintf("Light %q (%s) does not support gradients.", target.Metadata.Name, target.ID))
+		return diags
+	}
+	if len(action.Gradient.Points) > target.Gradient.PointsCapable {
+		diags.AddAttributeError(actionPath.AtName("gradient").AtName("points"), "Unsupported Scene Action",
+			fmt.Sprintf("Light %q (%s) supports at most %d gradient points, got %d.",
+				target.Metadata.Name, target.ID, target.Gradient.PointsCapable, len(action.Gradient.Points)))
+	}
+	return diags
+}
+
+// validateSceneActionEffects checks that the light lists the requested effects in its supported set.
+func validateSceneActionEffects(actionPath path.Path, action SceneActionModel, target *light.Light) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !action.Effect.IsNull() && !action.Effect.IsUnknown() {
+		var supported []string
+		if target.Effects != nil {
+			supported = target.Effects.EffectValues
+		}
+		if !slices.Contains(supported, action.Effect.ValueString()) {
+			diags.AddAttributeError(actionPath.AtName("effect"), "Unsupported Scene Action",
+				fmt.Sprintf("Light %q (%s) does not support the %q effect. Supported effects: %s.",
+					target.Metadata.Name, target.ID, action.Effect.ValueString(), formatSupportedValues(supported)))
+		}
+	}
+	if action.TimedEffect != nil && !action.TimedEffect.Effect.IsUnknown() {
+		var supported []string
+		if target.TimedEffects != nil {
+			supported = target.TimedEffects.EffectValues
+		}
+		if !slices.Contains(supported, action.TimedEffect.Effect.ValueString()) {
+			diags.AddAttributeError(actionPath.AtName("timed_effect").AtName("effect"), "Unsupported Scene Action",
+				fmt.Sprintf("Light %q (%s) does not support the %q timed effect. Supported timed effects: %s.",
+					target.Metadata.Name, target.ID, action.TimedEffect.Effect.ValueString(), formatSupportedValues(supported)))
+		}
+	}
+	return diags
+}
+
+// validateSceneActionColor checks the colors and color temperature of an action against the gamut and
+// mirek range of the light. Values out of range are errors, or warnings when the scene clamps them.
+func validateSceneActionColor(actionPath path.Path, action SceneActionModel, target *light.Light, clamp bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	outOfRange := func(attributePath path.Path, detail string) {
+		if clamp {
+			diags.AddAttributeWarning(attributePath, "Scene Action Out of Range", detail+" The closest supported value is applied instead.")
+			return
+		}
+		diags.AddAttributeError(attributePath, "Scene Action Out of Range",
+			detail+" Set clamp_to_capabilities to apply the closest supported value instead.")
+	}
+	checkColor := func(attributePath path.Path, c SceneActionColorModel) {
+		if target.Color == nil || target.Color.Gamut == nil || c.X.IsUnknown() || c.Y.IsUnknown() {
+			return
+		}
+		xy := color.XYCoord{X: c.X.ValueFloat64(), Y: c.Y.ValueFloat64()}
+		if gamutContains(target.Color.Gamut, xy) {
+			return
+		}
+		closest := clampToGamut(target.Color.Gamut, xy)
+		outOfRange(attributePath, fmt.Sprintf("The color x=%.4f, y=%.4f is outside the color gamut %s of light %q (%s). The closest supported color is x=%.4f, y=%.4f.",
+			xy.X, xy.Y, target.Color.GamutType, target.Metadata.Name, target.ID, closest.X, closest.Y))
+	}
+
+	if action.Color != nil {
+		if target.Color == nil {
+			diags.AddAttributeError(actionPath.AtName("color"), "Unsupported Scene Action",
+				fmt.Sprintf("Light %q (%s) does not support color, only color temperature or brightness.", target.Metadata.Name, target.ID))
+		} else {
+			checkColor(actionPath.AtName("color"), *action.Color)
+		}
+	}
+	if action.Gradient != nil {
+		for i, point := range action.Gradient.Points {
+			checkColor(actionPath.AtName("gradient").AtName("points").AtListIndex(i), point)
+		}
+	}
+
+	mirekPath, mirek, ok := sceneActionMirek(actionPath, action)
+	if !ok {
+		return diags
+	}
+	if target.ColorTemperature == nil {
+		diags.AddAttributeError(mirekPath, "Unsupported Scene Action",
+			fmt.Sprintf("Light %q (%s) does not support color temperature.", target.Metadata.Name, target.ID))
+		return diags
+	}
+	if mirekSchema := target.ColorTemperature.MirekSchema; mirekSchema != nil && (mirek < mirekSchema.MirekMinimum || mirek > mirekSchema.MirekMaximum) {
+		outOfRange(mirekPath, fmt.Sprintf("The color temperature of %d mirek (%d K) is outside the range of light %q (%s), which supports %d to %d mirek (%d K to %d K).",
+			mirek, color.MirekToKelvinRounded(int32(mirek)), target.Metadata.Name, target.ID,
+			mirekSchema.MirekMinimum, mirekSchema.MirekMaximum,
+			color.MirekToKelvinRounded(int32(mirekSchema.MirekMaximum)), color.MirekToKelvinRounded(int32(mirekSchema.MirekMinimum))))
+	}
+	return diags
+}
+
+// sceneActionMirek returns the color temperature of the action in mirek, and the attribute it is configured with.
+func sceneActionMirek(actionPath path.Path, action SceneActionModel) (path.Path, int, bool) {
+	if !action.ColorTemperatureMirek.IsNull() && !action.ColorTemperatureMirek.IsUnknown() {
+		return actionPath.AtName("color_temperature_mirek"), int(action.ColorTemperatureMirek.ValueInt32()), true
+	}
+	if !action.ColorTemperature.IsNull() && !action.ColorTemperature.IsUnknown() {
+		return actionPath.AtName("color_temperature"), action.ColorTemperature.Mirek(), true
+	}
+	return path.Path{}, 0, false
+}
+
+// clampSceneActions returns the actions with their colors and color temperatures clamped to the
+// capabilities of the target lights.
+func (s *SceneResource) clampSceneActions(ctx context.Context, actions map[string]SceneActionModel) (map[string]SceneActionModel, error) {
+	lights := newSceneLightLookup(s.client.LightService())
+	result := make(map[string]SceneActionModel, len(actions))
+	for targetID, action := range actions {
+		result[targetID] = action
+		if action.TargetType.ValueString() != "light" {
+			continue
+		}
+		target, err := lights.get(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if target != nil {
+			result[targetID] = clampSceneAction(action, target)
+		}
+	}
+	return result, nil
+}
+
+// clampSceneAction returns the action with its colors moved into the gamut of the light, and its color
+// temperature moved into the mirek range of the light. A clamped color temperature is always set in mirek.
+func clampSceneAction(action SceneActionModel, target *light.Light) SceneActionModel {
+	clampColor := func(c SceneActionColorModel) SceneActionColorModel {
+		if target.Color == nil || target.Color.Gamut == nil {
+			return c
+		}
+		xy := clampToGamut(target.Color.Gamut, color.XYCoord{X: c.X.ValueFloat64(), Y: c.Y.ValueFloat64()})
+		return SceneActionColorModel{X: huetypes.NewXYValue(xy.X), Y: huetypes.NewXYValue(xy.Y)}
+	}
+
+	if action.Color != nil {
+		clamped := clampColor(*action.Color)
+		action.Color = &clamped
+	}
+	if action.Gradient != nil {
+		points := make([]SceneActionColorModel, len(action.Gradient.Points))
+		for i, point := range action.Gradient.Points {
+			points[i] = clampColor(point)
+		}
+		action.Gradient = &SceneActionGradientModel{Points: points, Mode: action.Gradient.Mode}
+	}
+	if _, mirek, ok := sceneActionMirek(path.Empty(), action); ok && target.ColorTemperature != nil && target.ColorTemperature.MirekSchema != nil {
+		mirekSchema := target.ColorTemperature.MirekSchema
+		clamped := max(mirekSchema.MirekMinimum, min(mirek, mirekSchema.MirekMaximum))
+		if clamped != mirek {
+			action.ColorTemperature = huetypes.NewKelvinNull()
+			action.ColorTemperatureMirek = types.Int32Value(int32(clamped))
+		}
+	}
+	return action
+}
+
+// restoreClampedSceneAction keeps the configured values of an action where the bridge holds the values
+// they were clamped to, so clamping does not show up as drift.
+func restoreClampedSceneAction(read, configured, clamped SceneActionModel) SceneActionModel {
+	if read.Color != nil && configured.Color != nil && clamped.Color != nil && sceneColorsMatch(*read.Color, *clamped.Color) {
+		read.Color = configured.Color
+	}
+	if read.Gradient != nil && configured.Gradient != nil && clamped.Gradient != nil &&
+		slices.EqualFunc(read.Gradient.Points, clamped.Gradient.Points, sceneColorsMatch) {
+		read.Gradient.Points = configured.Gradient.Points
+	}
+	if !clamped.ColorTemperatureMirek.IsNull() {
+		_, readMirek, ok := sceneActionMirek(path.Empty(), read)
+		if ok && readMirek == int(clamped.ColorTemperatureMirek.ValueInt32()) {
+			read.ColorTemperature = configured.ColorTemperature
+			read.ColorTemperatureMirek = configured.ColorTemperatureMirek
+		}
+	}
+	return read
+}
+
+func sceneColorsMatch(a, b SceneActionColorModel) bool {
+	return math.Abs(a.X.ValueFloat64()-b.X.ValueFloat64()) <= gamutTolerance &&
+		math.Abs(a.Y.ValueFloat64()-b.Y.ValueFloat64()) <= gamutTolerance
+}
+
+func formatSupportedValues(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/color"
+	"github.com/richseviora/huego/pkg/resources/light"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+func TestValidateSceneActionCapabilities(t *testing.T) {
+	gradientStrip := &light.Light{
+		ID:       "strip",
+		Metadata: light.Metadata{Name: "TV Strip"},
+		Gradient: &light.Gradient{PointsCapable: 5},
+		Effects:  &light.Effects{EffectValues: []string{"no_effect", "candle", "fire", "prism"}},
+	}
+	whiteBulb := &light.Light{
+		ID:           "bulb",
+		Metadata:     light.Metadata{Name: "Hallway"},
+		TimedEffects: &light.TimedEffects{EffectValues: []string{"no_effect", "sunrise"}},
+	}
+	points := func(n int) []SceneActionColorModel {
+		result := make([]SceneActionColorModel, n)
+		for i := range result {
+			result[i] = SceneActionColorModel{X: huetypes.NewXYValue(0.3), Y: huetypes.NewXYValue(0.3)}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		action  SceneActionModel
+		target  *light.Light
+		wantErr bool
+	}{
+		{
+			name:   "plain action",
+			action: SceneActionModel{ColorTemperature: huetypes.NewKelvinValue(2700)},
+			target: whiteBulb,
+		},
+		{
+			name:   "gradient within capability",
+			action: SceneActionModel{Gradient: &SceneActionGradientModel{Points: points(5)}},
+			target: gradientStrip,
+		},
+		{
+			name:    "gradient exceeds capability",
+			action:  SceneActionModel{Gradient: &SceneActionGradientModel{Points: points(6)}},
+			target:  gradientStrip,
+			wantErr: true,
+		},
+		{
+			name:    "gradient on non-gradient light",
+			action:  SceneActionModel{Gradient: &SceneActionGradientModel{Points: points(2)}},
+			target:  whiteBulb,
+			wantErr: true,
+		},
+		{
+			name:   "supported effect",
+			action: SceneActionModel{Effect: types.StringValue("candle")},
+			target: gradientStrip,
+		},
+		{
+			name:    "unsupported effect",
+			action:  SceneActionModel{Effect: types.StringValue("opal")},
+			target:  gradientStrip,
+			wantErr: true,
+		},
+		{
+			name:   "supported timed effect",
+			action: SceneActionModel{TimedEffect: &SceneActionTimedEffectModel{Effect: types.StringValue("sunrise")}},
+			target: whiteBulb,
+		},
+		{
+			name:    "unsupported timed effect",
+			action:  SceneActionModel{TimedEffect: &SceneActionTimedEffectModel{Effect: types.StringValue("sunrise")}},
+			target:  gradientStrip,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actionPath := path.Root("actions").AtMapKey("target")
+			diags := validateSceneActionGradient(actionPath, tt.action, tt.target)
+			diags.Append(validateSceneActionEffects(actionPath, tt.action, tt.target)...)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validation errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSceneActionColor(t *testing.T) {
+	colorBulb := &light.Light{
+		ID:       "color",
+		Metadata: light.Metadata{Name: "Living Room"},
+		Color: &light.Color{
+			GamutType: "C",
+			Gamut: &light.Gamut{
+				Red:   color.XYCoord{X: 0.6915, Y: 0.3083},
+				Green: color.XYCoord{X: 0.17, Y: 0.7},
+				Blue:  color.XYCoord{X: 0.1532, Y: 0.0475},
+			},
+		},
+		ColorTemperature: &light.ColorTemperature{MirekSchema: &light.MirekSchema{MirekMinimum: 153, MirekMaximum: 500}},
+	}
+	ambianceBulb := &light.Light{
+		ID:               "ambiance",
+		Metadata:         light.Metadata{Name: "Hallway"},
+		ColorTemperature: &light.ColorTemperature{MirekSchema: &light.MirekSchema{MirekMinimum: 153, MirekMaximum: 454}},
+	}
+	xy := func(x, y float64) *SceneActionColorModel {
+		return &SceneActionColorModel{X: huetypes.NewXYValue(x), Y: huetypes.NewXYValue(y)}
+	}
+
+	tests := []struct {
+		name        string
+		action      SceneActionModel
+		target      *light.Light
+		clamp       bool
+		wantErr     bool
+		wantWarning bool
+	}{
+		{
+			name:   "color within gamut",
+			action: SceneActionModel{Color: xy(0.3127, 0.329)},
+			target: colorBulb,
+		},
+		{
+			name:    "color outside gamut",
+			action:  SceneActionModel{Color: xy(0.1, 0.8)},
+			target:  colorBulb,
+			wantErr: true,
+		},
+		{
+			name:        "color outside gamut with clamping",
+			action:      SceneActionModel{Color: xy(0.1, 0.8)},
+			target:      colorBulb,
+			clamp:       true,
+			wantWarning: true,
+		},
+		{
+			name:    "color on a color temperature light",
+			action:  SceneActionModel{Color: xy(0.3127, 0.329)},
+			target:  ambianceBulb,
+			clamp:   true,
+			wantErr: true,
+		},
+		{
+			name:   "color temperature within range",
+			action: SceneActionModel{ColorTemperature: huetypes.NewKelvinValue(2700)},
+			target: ambianceBulb,
+		},
+		{
+			name:    "color temperature outside range",
+			action:  SceneActionModel{ColorTemperature: huetypes.NewKelvinValue(2000)},
+			target:  ambianceBulb,
+			wantErr: true,
+		},
+		{
+			name:        "mirek outside range with clamping",
+			action:      SceneActionModel{ColorTemperatureMirek: types.Int32Value(500)},
+			target:      ambianceBulb,
+			clamp:       true,
+			wantWarning: true,
+		},
+		{
+			name:    "color temperature on a color only light",
+			action:  SceneActionModel{ColorTemperature: huetypes.NewKelvinValue(2700)},
+			target:  &light.Light{ID: "strip", Color: colorBulb.Color},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateSceneActionColor(path.Root("actions").AtMapKey("target"), tt.action, tt.target, tt.clamp)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validation errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if hasWarning := diags.WarningsCount() > 0; hasWarning != tt.wantWarning {
+				t.Errorf("validation warnings = %v, wantWarning %v", diags, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestClampSceneAction(t *testing.T) {
+	target := &light.Light{
+		ID: "color",
+		Color: &light.Color{
+			Gamut: &light.Gamut{
+				Red:   color.XYCoord{X: 0.6915, Y: 0.3083},
+				Green: color.XYCoord{X: 0.17, Y: 0.7},
+				Blue:  color.XYCoord{X: 0.1532, Y: 0.0475},
+			},
+		},
+		ColorTemperature: &light.ColorTemperature{MirekSchema: &light.MirekSchema{MirekMinimum: 153, MirekMaximum: 454}},
+	}
+	configured := SceneActionModel{
+		Color:            &SceneActionColorModel{X: huetypes.NewXYValue(0.8), Y: huetypes.NewXYValue(0.2)},
+		ColorTemperature: huetypes.NewKelvinValue(2000),
+	}
+
+	clamped := clampSceneAction(configured, target)
+	got := color.XYCoord{X: clamped.Color.X.ValueFloat64(), Y: clamped.Color.Y.ValueFloat64()}
+	if !gamutContains(target.Color.Gamut, got) {
+		t.Errorf("expected the clamped color %v to be within the gamut", got)
+	}
+	if !clamped.ColorTemperature.IsNull() || clamped.ColorTemperatureMirek.ValueInt32() != 454 {
+		t.Errorf("expected the color temperature to be clamped to 454 mirek, got %v / %v", clamped.ColorTemperature, clamped.ColorTemperatureMirek)
+	}
+	if configured.Color.X.ValueFloat64() != 0.8 {
+		t.Errorf("expected the configured action to be left unchanged, got %v", configured.Color.X)
+	}
+
+	read := SceneActionModel{
+		Color:            clamped.Color,
+		ColorTemperature: huetypes.NewKelvinFromMirek(454),
+	}
+	restored := restoreClampedSceneAction(read, configured, clamped)
+	if restored.Color != configured.Color || !restored.ColorTemperature.Equal(configured.ColorTemperature) {
+		t.Errorf("expected the clamped values to be restored to the configured values, got %+v", restored)
+	}
+}
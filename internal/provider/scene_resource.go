@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,7 +27,10 @@ import (
 	"github.com/richseviora/huego/pkg/resources/common"
 	"github.com/richseviora/huego/pkg/resources/light"
 	"github.com/richseviora/huego/pkg/resources/scene"
+	"maps"
+	"slices"
 	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
 )
 
 var _ resource.Resource = &SceneResource{}
@@ -40,8 +46,30 @@ type SceneResource struct {
 }
 
 type SceneActionColorModel struct {
-	X types.Float64 `tfsdk:"x"`
-	Y types.Float64 `tfsdk:"y"`
+	X huetypes.XY `tfsdk:"x"`
+	Y huetypes.XY `tfsdk:"y"`
+}
+
+type SceneActionGradientModel struct {
+	Points []SceneActionColorModel `tfsdk:"points"`
+	Mode   types.String            `tfsdk:"mode"`
+}
+
+type SceneActionTimedEffectModel struct {
+	Effect   types.String `tfsdk:"effect"`
+	Duration types.Int64  `tfsdk:"duration"`
+}
+
+type SceneActionDynamicsModel struct {
+	Duration types.Int64 `tfsdk:"duration"`
+}
+
+// SceneRecallModel holds the defaults used when the scene is recalled. The bridge does not store recall
+// defaults, so the duration is applied to every action without its own dynamics instead. A default recall action
+// and a brightness override cannot be stored on the scene at all, they are only supported when recalling the scene
+// with a philips_scene_activation.
+type SceneRecallModel struct {
+	Duration types.Int64 `tfsdk:"duration"`
 }
 
 type ResourceReference struct {
@@ -50,20 +78,32 @@ type ResourceReference struct {
 }
 
 type SceneActionModel struct {
-	TargetId         types.String           `tfsdk:"target_id"`
-	TargetType       types.String           `tfsdk:"target_type"`
-	Brightness       types.Float64          `tfsdk:"brightness"`
-	On               types.Bool             `tfsdk:"on"`
-	Color            *SceneActionColorModel `tfsdk:"color"`
-	ColorTemperature types.Int32            `tfsdk:"color_temperature"`
+	TargetType            types.String                 `tfsdk:"target_type"`
+	Brightness            huetypes.Brightness          `tfsdk:"brightness"`
+	On                    types.Bool                   `tfsdk:"on"`
+	Color                 *SceneActionColorModel       `tfsdk:"color"`
+	ColorTemperature      huetypes.Kelvin              `tfsdk:"color_temperature"`
+	ColorTemperatureMirek types.Int32                  `tfsdk:"color_temperature_mirek"`
+	Gradient              *SceneActionGradientModel    `tfsdk:"gradient"`
+	Effect                types.String                 `tfsdk:"effect"`
+	TimedEffect           *SceneActionTimedEffectModel `tfsdk:"timed_effect"`
+	Dynamics              *SceneActionDynamicsModel    `tfsdk:"dynamics"`
 }
 
 type SceneResourceModel struct {
-	Id      types.String       `tfsdk:"id"`
-	Type    types.String       `tfsdk:"type"`
-	Name    types.String       `tfsdk:"name"`
-	Actions []SceneActionModel `tfsdk:"actions"`
-	Group   *ResourceReference `tfsdk:"group"`
+	Id          types.String                `tfsdk:"id"`
+	Type        types.String                `tfsdk:"type"`
+	Name        types.String                `tfsdk:"name"`
+	Actions     map[string]SceneActionModel `tfsdk:"actions"`
+	Group       *ResourceReference          `tfsdk:"group"`
+	Palette     *ScenePaletteModel          `tfsdk:"palette"`
+	Speed       types.Float64               `tfsdk:"speed"`
+	AutoDynamic types.Bool                  `tfsdk:"auto_dynamic"`
+	Recall      *SceneRecallModel           `tfsdk:"recall"`
+
+	ClampToCapabilities  types.Bool        `tfsdk:"clamp_to_capabilities"`
+	DefaultAction        *SceneActionModel `tfsdk:"default_action"`
+	DefaultActionTargets types.Set         `tfsdk:"default_action_targets"`
 }
 
 func (s *SceneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -73,91 +113,251 @@ func (s *SceneResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (s *SceneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A representation of a Philips Hue scene.",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+		Version:             1,
+		Attributes: sceneAttributes(schema.MapNestedAttribute{
+			Required:    true,
+			Description: "The actions to perform when the scene is triggered, keyed by the target ID to apply the action to.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: sceneActionAttributes(),
+			},
+		}),
+	}
+}
+
+// sceneAttributes returns the scene attributes for the given shape of the actions attribute.
+func sceneAttributes(actions schema.Attribute) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"type": schema.StringAttribute{
+			Computed: true,
+			Default:  stringdefault.StaticString("scene"),
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the Scene in the Hue Bridge.",
+		},
+		"group": schema.ObjectAttribute{
+			Required:    true,
+			Description: "The group this scene belongs to.",
+			AttributeTypes: map[string]attr.Type{
+				"id":   types.StringType,
+				"type": types.StringType,
+			},
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.RequiresReplace(),
+			},
+		},
+		"actions": actions,
+		"palette": scenePaletteSchema(),
+		"speed": schema.Float64Attribute{
+			Optional:    true,
+			Description: "The speed of the dynamic palette from 0 to 1.",
+			Validators: []validator.Float64{
+				float64validator.Between(0, 1),
+			},
+		},
+		"auto_dynamic": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Whether the scene starts as a dynamic palette when it is recalled.",
+		},
+		"default_action": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The action to apply to lights of the group that have no action in `actions`. Without a default action, such lights are left unchanged when the scene is recalled.",
+			Attributes:  sceneDefaultActionAttributes(),
+		},
+		"default_action_targets": schema.SetAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The IDs of the lights of the group the default action is applied to.",
+		},
+		"recall": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The defaults used when the scene is recalled. The duration is applied as the transition of every action without its own `dynamics`, so the scene fades in wherever it is recalled from. The bridge cannot store a default recall action or a brightness override for a scene, set `action` and `brightness` on a `philips_scene_activation` instead.",
+			Attributes: map[string]schema.Attribute{
+				"duration": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The transition duration in milliseconds.",
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
 				},
 			},
-			"type": schema.StringAttribute{
-				Computed: true,
-				Default:  stringdefault.StaticString("scene"),
+		},
+		"clamp_to_capabilities": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Whether colors and color temperatures outside the range of a target light are clamped to the closest supported value instead of failing the plan. Clamped values are reported as warnings.",
+		},
+	}
+}
+
+// sceneDefaultActionAttributes returns the attributes of the default action, which always targets lights.
+func sceneDefaultActionAttributes() map[string]schema.Attribute {
+	attributes := sceneActionAttributes()
+	attributes["target_type"] = schema.StringAttribute{
+		Computed:    true,
+		Default:     stringdefault.StaticString("light"),
+		Description: "The target type of the default action, always `light`.",
+	}
+	return attributes
+}
+
+// sceneActionAttributes returns the attributes of a single scene action.
+func sceneActionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"target_type": schema.StringAttribute{
+			Required:    true,
+			Description: "The target type to apply the action to.",
+		},
+		"brightness": schema.Float64Attribute{
+			Required:    true,
+			CustomType:  huetypes.BrightnessType{},
+			Description: "The brightness to apply to the target from 0 to 100",
+			Validators: []validator.Float64{
+				float64validator.Between(0, 100),
 			},
-			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "The name of the Scene in the Hue Bridge.",
+		},
+		"on": schema.BoolAttribute{
+			Required:    true,
+			Description: "Whether the target should be turned on or off.",
+		},
+		"color": schema.SingleNestedAttribute{
+			Optional: true,
+			Validators: []validator.Object{
+				objectvalidator.ExactlyOneOf(
+					path.Expressions{
+						path.MatchRelative().AtParent().AtName("color_temperature"),
+						path.MatchRelative().AtParent().AtName("color_temperature_mirek"),
+						path.MatchRelative().AtParent().AtName("gradient"),
+					}...,
+				),
 			},
-			"group": schema.ObjectAttribute{
-				Required:    true,
-				Description: "The group this scene belongs to.",
-				AttributeTypes: map[string]attr.Type{
-					"id":   types.StringType,
-					"type": types.StringType,
+			Attributes: map[string]schema.Attribute{
+				"x": schema.Float64Attribute{
+					Required:    true,
+					CustomType:  huetypes.XYType{},
+					Description: "The x value of the color to apply to the target.",
+					Validators: []validator.Float64{
+						float64validator.Between(0, 1),
+					},
 				},
-				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
+				"y": schema.Float64Attribute{
+					Required:    true,
+					CustomType:  huetypes.XYType{},
+					Description: "The y value of the color to apply to the target.",
+					Validators: []validator.Float64{
+						float64validator.Between(0, 1),
+					},
 				},
 			},
-			"actions": schema.ListNestedAttribute{
-				Required:    true,
-				Description: "The actions and targets to perform when the scene is triggered.",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"target_id": schema.StringAttribute{
-							Required:    true,
-							Description: "The target ID to apply the action to.",
-						},
-						"target_type": schema.StringAttribute{
-							Required:    true,
-							Description: "The target type to apply the action to.",
-						},
-						"brightness": schema.Float64Attribute{
-							Required:    true,
-							Description: "The brightness to apply to the target from 0 to 100",
-							Validators: []validator.Float64{
-								float64validator.Between(0, 100),
-							},
-						},
-						"on": schema.BoolAttribute{
-							Required:    true,
-							Description: "Whether the target should be turned on or off.",
-						},
-						"color": schema.SingleNestedAttribute{
-							Optional: true,
-							Validators: []validator.Object{
-								objectvalidator.ExactlyOneOf(
-									path.Expressions{
-										path.MatchRelative().AtParent().AtName("color_temperature"),
-									}...,
-								),
-							},
-							Attributes: map[string]schema.Attribute{
-								"x": schema.Float64Attribute{
-									Required:    true,
-									Description: "The x value of the color to apply to the target.",
-									Validators: []validator.Float64{
-										float64validator.Between(0, 1),
-									},
-								},
-								"y": schema.Float64Attribute{
-									Required:    true,
-									Description: "The y value of the color to apply to the target.",
-									Validators: []validator.Float64{
-										float64validator.Between(0, 1),
-									},
+		},
+		"color_temperature": schema.Int32Attribute{
+			Optional:    true,
+			CustomType:  huetypes.KelvinType{},
+			Description: "The color temperature to apply to the target from 2000 K to 6500 K. The bridge stores color temperatures in mirek, so the value may read back a few kelvin off.",
+			Validators: []validator.Int32{
+				int32validator.Between(2000, 6500),
+			},
+		},
+		"color_temperature_mirek": schema.Int32Attribute{
+			Optional:    true,
+			Description: "The color temperature to apply to the target in mirek from 153 to 500, for exact control over the value stored by the bridge.",
+			Validators: []validator.Int32{
+				int32validator.Between(153, 500),
+			},
+		},
+		"gradient": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The gradient to apply to a gradient capable target, such as a gradient lightstrip.",
+			Attributes: map[string]schema.Attribute{
+				"points": schema.ListNestedAttribute{
+					Required:    true,
+					Description: "The xy colors of the gradient points. The number of points must not exceed the number of points supported by the target.",
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(2),
+					},
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"x": schema.Float64Attribute{
+								Required:    true,
+								CustomType:  huetypes.XYType{},
+								Description: "The x value of the gradient point color.",
+								Validators: []validator.Float64{
+									float64validator.Between(0, 1),
 								},
 							},
-						},
-						"color_temperature": schema.Int32Attribute{
-							Optional:    true,
-							Description: "The color temperature to apply to the target from 2000 K to 6500 K",
-							Validators: []validator.Int32{
-								int32validator.Between(2000, 6500),
+							"y": schema.Float64Attribute{
+								Required:    true,
+								CustomType:  huetypes.XYType{},
+								Description: "The y value of the gradient point color.",
+								Validators: []validator.Float64{
+									float64validator.Between(0, 1),
+								},
 							},
 						},
 					},
 				},
+				"mode": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Default:     stringdefault.StaticString("interpolated_palette"),
+					Description: "How the gradient points are distributed over the target.",
+					Validators: []validator.String{
+						stringvalidator.OneOf(
+							"interpolated_palette",
+							"interpolated_palette_mirrored",
+							"random_pixelated",
+							"segmented_palette",
+						),
+					},
+				},
+			},
+		},
+		"effect": schema.StringAttribute{
+			Optional:    true,
+			Description: "The effect to apply to the target. The target must list the effect in its supported `effects`.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					"no_effect", "candle", "fire", "prism", "sparkle", "opal", "glisten",
+					"underwater", "cosmos", "sunbeam", "enchant",
+				),
+			},
+		},
+		"dynamics": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "The transition to the action when the scene is recalled.",
+			Attributes: map[string]schema.Attribute{
+				"duration": schema.Int64Attribute{
+					Required:    true,
+					Description: "The transition duration in milliseconds.",
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
+				},
+			},
+		},
+		"timed_effect": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "A timed effect to apply to the target, such as a sunrise.",
+			Attributes: map[string]schema.Attribute{
+				"effect": schema.StringAttribute{
+					Required:    true,
+					Description: "The timed effect to apply.",
+					Validators: []validator.String{
+						stringvalidator.OneOf("no_effect", "sunrise", "sunset"),
+					},
+				},
+				"duration": schema.Int64Attribute{
+					Optional:    true,
+					Description: "The duration of the timed effect in milliseconds.",
+					Validators: []validator.Int64{
+						int64validator.Between(0, 21600000),
+					},
+				},
 			},
 		},
 	}
@@ -183,7 +383,15 @@ func (s *SceneResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
-	createObj := s.createSceneCreateObj(data)
+	sent, err := s.sceneDataToSend(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading scene target",
+			"Could not read the scene target lights: "+err.Error(),
+		)
+		return
+	}
+	createObj := s.createSceneCreateObj(sent)
 	newObj, err := s.client.SceneService().CreateScene(ctx, createObj)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -197,6 +405,30 @@ func (s *SceneResource) Create(ctx context.Context, req resource.CreateRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// sceneDataToSend returns the scene to send to the bridge. The default action is added for the lights
+// without an action, and the actions are clamped to the capabilities of the target lights when the
+// scene opts in to clamping. Default action targets that are unknown until apply are resolved on data.
+func (s *SceneResource) sceneDataToSend(ctx context.Context, data *SceneResourceModel) (SceneResourceModel, error) {
+	if data.DefaultActionTargets.IsUnknown() {
+		groupLightIDs, err := s.sceneGroupLightIDs(ctx, data.Group)
+		if err != nil {
+			return *data, err
+		}
+		data.DefaultActionTargets = defaultActionTargets(ctx, *data, groupLightIDs)
+	}
+	sent := *data
+	sent.Actions = withDefaultActions(ctx, *data)
+	if !data.ClampToCapabilities.ValueBool() {
+		return sent, nil
+	}
+	actions, err := s.clampSceneActions(ctx, sent.Actions)
+	if err != nil {
+		return sent, err
+	}
+	sent.Actions = actions
+	return sent, nil
+}
+
 func (s *SceneResource) createSceneCreateObj(data SceneResourceModel) scene.SceneCreate {
 	actionTargets := s.createSceneActionObj(data)
 
@@ -209,6 +441,9 @@ func (s *SceneResource) createSceneCreateObj(data SceneResourceModel) scene.Scen
 			RID:   data.Group.Rid.ValueString(),
 			RType: data.Group.Rtype.ValueString(),
 		},
+		Palette:     createScenePaletteObj(data.Palette),
+		Speed:       data.Speed.ValueFloat64Pointer(),
+		AutoDynamic: data.AutoDynamic.ValueBoolPointer(),
 	}
 	return createObj
 }
@@ -220,13 +455,18 @@ func (s *SceneResource) createSceneUpdateObj(data SceneResourceModel) scene.Scen
 		Metadata: scene.SceneMetadata{
 			Name: data.Name.ValueString(),
 		},
-		Actions: actionTargets,
+		Actions:     actionTargets,
+		Palette:     createScenePaletteObj(data.Palette),
+		Speed:       data.Speed.ValueFloat64Pointer(),
+		AutoDynamic: data.AutoDynamic.ValueBoolPointer(),
 	}
 }
 
 func (s *SceneResource) createSceneActionObj(data SceneResourceModel) []scene.ActionTarget {
-	actionTargets := make([]scene.ActionTarget, len(data.Actions))
-	for i, action := range data.Actions {
+	actionTargets := make([]scene.ActionTarget, 0, len(data.Actions))
+	// Actions are sent in a stable order, the bridge keeps its own order regardless.
+	for _, targetID := range slices.Sorted(maps.Keys(data.Actions)) {
+		action := data.Actions[targetID]
 		newAction := scene.Action{
 			On: &scene.On{
 				On: action.On.ValueBool(),
@@ -243,17 +483,59 @@ func (s *SceneResource) createSceneActionObj(data SceneResourceModel) []scene.Ac
 		}
 		if !action.ColorTemperature.IsNull() && !action.ColorTemperature.IsUnknown() {
 			newAction.ColorTemperature = &light.ColorTemperature{
-				Mirek: int(color.KelvinToMirekRounded(int32(action.ColorTemperature.ValueInt32()))),
+				Mirek: action.ColorTemperature.Mirek(),
+			}
+		}
+		if !action.ColorTemperatureMirek.IsNull() && !action.ColorTemperatureMirek.IsUnknown() {
+			newAction.ColorTemperature = &light.ColorTemperature{
+				Mirek: int(action.ColorTemperatureMirek.ValueInt32()),
+			}
+		}
+		if action.Gradient != nil {
+			points := make([]light.GradientPoint, len(action.Gradient.Points))
+			for j, point := range action.Gradient.Points {
+				points[j] = light.GradientPoint{
+					Color: light.Color{
+						XY: color.XYCoord{
+							X: point.X.ValueFloat64(),
+							Y: point.Y.ValueFloat64(),
+						},
+					},
+				}
+			}
+			newAction.Gradient = &light.Gradient{
+				Points: points,
+				Mode:   action.Gradient.Mode.ValueString(),
+			}
+		}
+		if !action.Effect.IsNull() && !action.Effect.IsUnknown() {
+			newAction.Effects = &light.Effects{
+				Effect: action.Effect.ValueString(),
+			}
+		}
+		if action.TimedEffect != nil {
+			newAction.TimedEffects = &light.TimedEffects{
+				Effect:   action.TimedEffect.Effect.ValueString(),
+				Duration: int(action.TimedEffect.Duration.ValueInt64()),
+			}
+		}
+		if action.Dynamics != nil {
+			newAction.Dynamics = &scene.Dynamics{
+				Duration: int(action.Dynamics.Duration.ValueInt64()),
+			}
+		} else if data.Recall != nil && !data.Recall.Duration.IsNull() && !data.Recall.Duration.IsUnknown() {
+			newAction.Dynamics = &scene.Dynamics{
+				Duration: int(data.Recall.Duration.ValueInt64()),
 			}
 		}
 		actionTarget := scene.ActionTarget{
 			Target: scene.Target{
-				Rid:   action.TargetId.ValueString(),
+				Rid:   targetID,
 				Rtype: action.TargetType.ValueString(),
 			},
 			Action: newAction,
 		}
-		actionTargets[i] = actionTarget
+		actionTargets = append(actionTargets, actionTarget)
 	}
 	return actionTargets
 }
@@ -284,38 +566,115 @@ func (s *SceneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		Rid:   types.StringValue(result.Group.RID),
 		Rtype: types.StringValue(result.Group.RType),
 	}
-	actions := make([]SceneActionModel, len(result.Actions))
-	for i, action := range result.Actions {
+	var clamped map[string]SceneActionModel
+	if data.ClampToCapabilities.ValueBool() {
+		clamped, err = s.clampSceneActions(ctx, data.Actions)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading scene target",
+				"Could not read the target lights of scene ID "+data.Id.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	var defaultTargets []string
+	if !data.DefaultActionTargets.IsNull() {
+		resp.Diagnostics.Append(data.DefaultActionTargets.ElementsAs(ctx, &defaultTargets, false)...)
+	}
+	actions := make(map[string]SceneActionModel, len(result.Actions))
+	for _, action := range result.Actions {
+		prior, configured := data.Actions[action.Target.Rid]
+		if !configured && slices.Contains(defaultTargets, action.Target.Rid) {
+			// Lights that receive the default action are tracked by default_action_targets.
+			continue
+		}
 		var onValue types.Bool
 		if action.Action.On != nil {
 			onValue = types.BoolValue(action.Action.On.On)
 		}
-		var colorTemp types.Int32
+		colorTemp := huetypes.NewKelvinNull()
+		var colorTempMirek types.Int32
 		if action.Action.ColorTemperature != nil {
+			// The color temperature is read back in the unit the configuration uses.
 			mirek := action.Action.ColorTemperature.Mirek
-			kelvin := color.MirekToKelvinRounded(int32(mirek))
-			colorTemp = types.Int32Value(int32(kelvin))
+			if configured && !prior.ColorTemperatureMirek.IsNull() {
+				colorTempMirek = types.Int32Value(int32(mirek))
+			} else {
+				colorTemp = huetypes.NewKelvinFromMirek(mirek)
+			}
 		}
 		var color *SceneActionColorModel
 		if action.Action.Color != nil {
 			color = &SceneActionColorModel{
-				X: types.Float64Value(action.Action.Color.XY.X),
-				Y: types.Float64Value(action.Action.Color.XY.Y),
+				X: huetypes.NewXYValue(action.Action.Color.XY.X),
+				Y: huetypes.NewXYValue(action.Action.Color.XY.Y),
+			}
+		}
+		var gradient *SceneActionGradientModel
+		if action.Action.Gradient != nil {
+			points := make([]SceneActionColorModel, len(action.Action.Gradient.Points))
+			for j, point := range action.Action.Gradient.Points {
+				points[j] = SceneActionColorModel{
+					X: huetypes.NewXYValue(point.Color.XY.X),
+					Y: huetypes.NewXYValue(point.Color.XY.Y),
+				}
+			}
+			gradient = &SceneActionGradientModel{
+				Points: points,
+				Mode:   types.StringValue(action.Action.Gradient.Mode),
+			}
+		}
+		var effect types.String
+		if action.Action.Effects != nil {
+			effect = types.StringValue(action.Action.Effects.Effect)
+		}
+		var timedEffect *SceneActionTimedEffectModel
+		if action.Action.TimedEffects != nil {
+			timedEffect = &SceneActionTimedEffectModel{
+				Effect: types.StringValue(action.Action.TimedEffects.Effect),
+			}
+			if action.Action.TimedEffects.Duration != 0 {
+				timedEffect.Duration = types.Int64Value(int64(action.Action.TimedEffects.Duration))
+			}
+		}
+		// Dynamics are only tracked once they are managed by Terraform, the recall duration also sets them.
+		var dynamics *SceneActionDynamicsModel
+		if configured && prior.Dynamics != nil && action.Action.Dynamics != nil {
+			dynamics = &SceneActionDynamicsModel{
+				Duration: types.Int64Value(int64(action.Action.Dynamics.Duration)),
 			}
 		}
 		model := SceneActionModel{
-			TargetId:         types.StringValue(action.Target.Rid),
-			TargetType:       types.StringValue(action.Target.Rtype),
-			On:               onValue,
-			Brightness:       types.Float64Value(action.Action.Dimming.Brightness),
-			Color:            color,
-			ColorTemperature: colorTemp,
+			TargetType:            types.StringValue(action.Target.Rtype),
+			On:                    onValue,
+			Brightness:            huetypes.NewBrightnessValue(action.Action.Dimming.Brightness),
+			Color:                 color,
+			ColorTemperature:      colorTemp,
+			ColorTemperatureMirek: colorTempMirek,
+			Gradient:              gradient,
+			Effect:                effect,
+			TimedEffect:           timedEffect,
+			Dynamics:              dynamics,
+		}
+		if clampedAction, ok := clamped[action.Target.Rid]; ok {
+			model = restoreClampedSceneAction(model, prior, clampedAction)
 		}
-		actions[i] = model
+		actions[action.Target.Rid] = model
 	}
 
 	tflog.Trace(ctx, "actions:", map[string]interface{}{"actions": actions})
 
+	// Dynamic settings are only tracked once they are managed by Terraform.
+	if data.Palette != nil {
+		data.Palette = createScenePaletteModel(result.Palette)
+	}
+	if !data.Speed.IsNull() {
+		data.Speed = types.Float64Value(result.Speed)
+	}
+	if !data.AutoDynamic.IsNull() {
+		data.AutoDynamic = types.BoolValue(result.AutoDynamic)
+	}
+
 	data.Actions = actions
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -327,9 +686,17 @@ func (s *SceneResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	update := s.createSceneUpdateObj(data)
+	sent, err := s.sceneDataToSend(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading scene target",
+			"Could not read the scene target lights: "+err.Error(),
+		)
+		return
+	}
+	update := s.createSceneUpdateObj(sent)
 
-	_, err := s.client.SceneService().UpdateScene(ctx, data.Id.ValueString(), update)
+	_, err = s.client.SceneService().UpdateScene(ctx, data.Id.ValueString(), update)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating scene",
@@ -95,16 +95,15 @@ func testUnitExampleResourceConfig() string {
 resource "philips_scene" "test" {
   name = "test scene"
   group = { id: "abc", type: "room" }
-  actions = [
-  {
-  target_id = "abc"
+  actions = {
+  "abc" = {
   target_type = "light"
   on = true
   brightness = 100
   color_temperature = 2500
   color = { x = 0.31271592, y = 0.3290015 }
   }
-]
+}
 }
 `
 }
@@ -124,7 +123,7 @@ device_ids = [philips_light.test.device_id]
 
 resource "philips_scene" "test" {
   name = "test scene"
- actions = []
+ actions = {}
  group = philips_room.room.reference
 }
 `, configurableAttribute)
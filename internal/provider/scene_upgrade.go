@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+var _ resource.ResourceWithUpgradeState = &SceneResource{}
+
+// The version 0 models are frozen copies of the scene models before actions were keyed by target ID. They must not
+// follow later changes to the scene schema, as they decode state written by the version 0 schema.
+
+type sceneColorModelV0 struct {
+	X types.Float64 `tfsdk:"x"`
+	Y types.Float64 `tfsdk:"y"`
+}
+
+type sceneGradientModelV0 struct {
+	Points []sceneColorModelV0 `tfsdk:"points"`
+	Mode   types.String        `tfsdk:"mode"`
+}
+
+type sceneTimedEffectModelV0 struct {
+	Effect   types.String `tfsdk:"effect"`
+	Duration types.Int64  `tfsdk:"duration"`
+}
+
+type sceneActionModelV0 struct {
+	TargetId         types.String             `tfsdk:"target_id"`
+	TargetType       types.String             `tfsdk:"target_type"`
+	Brightness       types.Float64            `tfsdk:"brightness"`
+	On               types.Bool               `tfsdk:"on"`
+	Color            *sceneColorModelV0       `tfsdk:"color"`
+	ColorTemperature types.Int32              `tfsdk:"color_temperature"`
+	Gradient         *sceneGradientModelV0    `tfsdk:"gradient"`
+	Effect           types.String             `tfsdk:"effect"`
+	TimedEffect      *sceneTimedEffectModelV0 `tfsdk:"timed_effect"`
+}
+
+type scenePaletteColorModelV0 struct {
+	X          types.Float64 `tfsdk:"x"`
+	Y          types.Float64 `tfsdk:"y"`
+	Brightness types.Float64 `tfsdk:"brightness"`
+}
+
+type scenePaletteColorTemperatureModelV0 struct {
+	ColorTemperature types.Int32   `tfsdk:"color_temperature"`
+	Brightness       types.Float64 `tfsdk:"brightness"`
+}
+
+type scenePaletteModelV0 struct {
+	Colors            []scenePaletteColorModelV0            `tfsdk:"colors"`
+	ColorTemperatures []scenePaletteColorTemperatureModelV0 `tfsdk:"color_temperatures"`
+	Dimming           []types.Float64                       `tfsdk:"dimming"`
+}
+
+type sceneResourceModelV0 struct {
+	Id          types.String         `tfsdk:"id"`
+	Type        types.String         `tfsdk:"type"`
+	Name        types.String         `tfsdk:"name"`
+	Actions     []sceneActionModelV0 `tfsdk:"actions"`
+	Group       *ResourceReference   `tfsdk:"group"`
+	Palette     *scenePaletteModelV0 `tfsdk:"palette"`
+	Speed       types.Float64        `tfsdk:"speed"`
+	AutoDynamic types.Bool           `tfsdk:"auto_dynamic"`
+}
+
+// sceneSchemaV0 is the scene schema of version 0, where actions were a list.
+func sceneSchemaV0() *schema.Schema {
+	colorAttributes := map[string]schema.Attribute{
+		"x": schema.Float64Attribute{Required: true},
+		"y": schema.Float64Attribute{Required: true},
+	}
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"type": schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+			"group": schema.ObjectAttribute{
+				Required: true,
+				AttributeTypes: map[string]attr.Type{
+					"id":   types.StringType,
+					"type": types.StringType,
+				},
+			},
+			"palette": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"colors": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"x":          schema.Float64Attribute{Required: true},
+								"y":          schema.Float64Attribute{Required: true},
+								"brightness": schema.Float64Attribute{Required: true},
+							},
+						},
+					},
+					"color_temperatures": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"color_temperature": schema.Int32Attribute{Required: true},
+								"brightness":        schema.Float64Attribute{Required: true},
+							},
+						},
+					},
+					"dimming": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.Float64Type,
+					},
+				},
+			},
+			"speed":        schema.Float64Attribute{Optional: true},
+			"auto_dynamic": schema.BoolAttribute{Optional: true},
+			"actions": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"target_id":         schema.StringAttribute{Required: true},
+						"target_type":       schema.StringAttribute{Required: true},
+						"brightness":        schema.Float64Attribute{Required: true},
+						"on":                schema.BoolAttribute{Required: true},
+						"color":             schema.SingleNestedAttribute{Optional: true, Attributes: colorAttributes},
+						"color_temperature": schema.Int32Attribute{Optional: true},
+						"gradient": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"points": schema.ListNestedAttribute{
+									Required:     true,
+									NestedObject: schema.NestedAttributeObject{Attributes: colorAttributes},
+								},
+								"mode": schema.StringAttribute{Optional: true, Computed: true},
+							},
+						},
+						"effect": schema.StringAttribute{Optional: true},
+						"timed_effect": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"effect":   schema.StringAttribute{Required: true},
+								"duration": schema.Int64Attribute{Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *SceneResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored the actions as a list, version 1 keys them by target ID.
+		0: {
+			PriorSchema:   sceneSchemaV0(),
+			StateUpgrader: upgradeSceneStateV0,
+		},
+	}
+}
+
+func upgradeSceneStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior sceneResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actions := make(map[string]SceneActionModel, len(prior.Actions))
+	for _, action := range prior.Actions {
+		targetID := action.TargetId.ValueString()
+		if _, ok := actions[targetID]; ok {
+			resp.Diagnostics.AddError(
+				"Error upgrading scene",
+				"Could not upgrade scene ID "+prior.Id.ValueString()+": it has more than one action for target ID "+targetID+". Remove the duplicate actions with the previous provider version before upgrading.")
+			return
+		}
+		actions[targetID] = upgradeSceneActionV0(action)
+	}
+
+	upgraded := SceneResourceModel{
+		Id:          prior.Id,
+		Type:        prior.Type,
+		Name:        prior.Name,
+		Actions:     actions,
+		Group:       prior.Group,
+		Palette:     upgradeScenePaletteV0(prior.Palette),
+		Speed:       prior.Speed,
+		AutoDynamic: prior.AutoDynamic,
+
+		ClampToCapabilities:  types.BoolNull(),
+		DefaultActionTargets: types.SetNull(types.StringType),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+}
+
+func upgradeSceneColorV0(c sceneColorModelV0) SceneActionColorModel {
+	return SceneActionColorModel{
+		X: huetypes.XY{Float64Value: c.X},
+		Y: huetypes.XY{Float64Value: c.Y},
+	}
+}
+
+func upgradeSceneActionV0(action sceneActionModelV0) SceneActionModel {
+	upgraded := SceneActionModel{
+		TargetType:            action.TargetType,
+		Brightness:            huetypes.Brightness{Float64Value: action.Brightness},
+		On:                    action.On,
+		ColorTemperature:      huetypes.Kelvin{Int32Value: action.ColorTemperature},
+		ColorTemperatureMirek: types.Int32Null(),
+		Effect:                action.Effect,
+	}
+	if action.Color != nil {
+		color := upgradeSceneColorV0(*action.Color)
+		upgraded.Color = &color
+	}
+	if action.Gradient != nil {
+		points := make([]SceneActionColorModel, len(action.Gradient.Points))
+		for i, point := range action.Gradient.Points {
+			points[i] = upgradeSceneColorV0(point)
+		}
+		upgraded.Gradient = &SceneActionGradientModel{Points: points, Mode: action.Gradient.Mode}
+	}
+	if action.TimedEffect != nil {
+		upgraded.TimedEffect = &SceneActionTimedEffectModel{
+			Effect:   action.TimedEffect.Effect,
+			Duration: action.TimedEffect.Duration,
+		}
+	}
+	return upgraded
+}
+
+func upgradeScenePaletteV0(palette *scenePaletteModelV0) *ScenePaletteModel {
+	if palette == nil {
+		return nil
+	}
+	// Lists that were not set stay null.
+	upgraded := &ScenePaletteModel{}
+	for _, c := range palette.Colors {
+		upgraded.Colors = append(upgraded.Colors, ScenePaletteColorModel{
+			X:          huetypes.XY{Float64Value: c.X},
+			Y:          huetypes.XY{Float64Value: c.Y},
+			Brightness: huetypes.Brightness{Float64Value: c.Brightness},
+		})
+	}
+	for _, ct := range palette.ColorTemperatures {
+		upgraded.ColorTemperatures = append(upgraded.ColorTemperatures, ScenePaletteColorTemperatureModel{
+			ColorTemperature: huetypes.Kelvin{Int32Value: ct.ColorTemperature},
+			Brightness:       huetypes.Brightness{Float64Value: ct.Brightness},
+		})
+	}
+	for _, d := range palette.Dimming {
+		upgraded.Dimming = append(upgraded.Dimming, huetypes.Brightness{Float64Value: d})
+	}
+	return upgraded
+}
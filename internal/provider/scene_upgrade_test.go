@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+func TestSceneResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &SceneResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	diags := prior.Set(ctx, &sceneResourceModelV0{
+		Id:   types.StringValue("scene-id"),
+		Type: types.StringValue("scene"),
+		Name: types.StringValue("Bedroom Reading"),
+		Actions: []sceneActionModelV0{
+			{
+				TargetId:         types.StringValue("light-b"),
+				TargetType:       types.StringValue("light"),
+				Brightness:       types.Float64Value(100),
+				On:               types.BoolValue(true),
+				ColorTemperature: types.Int32Value(2200),
+			},
+			{
+				TargetId:   types.StringValue("light-a"),
+				TargetType: types.StringValue("light"),
+				Brightness: types.Float64Value(100),
+				On:         types.BoolValue(false),
+				Color:      &sceneColorModelV0{X: types.Float64Value(0.4), Y: types.Float64Value(0.5)},
+			},
+		},
+		Group: &ResourceReference{
+			Rid:   types.StringValue("room-id"),
+			Rtype: types.StringValue("room"),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error setting prior state: %v", diags)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error upgrading state: %v", resp.Diagnostics)
+	}
+
+	var upgraded SceneResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("unexpected error reading upgraded state: %v", diags)
+	}
+	if len(upgraded.Actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d", len(upgraded.Actions))
+	}
+	if !upgraded.Actions["light-a"].On.Equal(types.BoolValue(false)) {
+		t.Errorf("expected light-a to be off, got %v", upgraded.Actions["light-a"].On)
+	}
+	if !upgraded.Actions["light-b"].On.Equal(types.BoolValue(true)) {
+		t.Errorf("expected light-b to be on, got %v", upgraded.Actions["light-b"].On)
+	}
+	if !upgraded.Actions["light-b"].ColorTemperature.Equal(huetypes.NewKelvinValue(2200)) {
+		t.Errorf("expected light-b to keep its color temperature, got %v", upgraded.Actions["light-b"].ColorTemperature)
+	}
+	if color := upgraded.Actions["light-a"].Color; color == nil || !color.X.Equal(huetypes.NewXYValue(0.4)) {
+		t.Errorf("expected light-a to keep its color, got %+v", color)
+	}
+	if upgraded.Id.ValueString() != "scene-id" || upgraded.Group.Rid.ValueString() != "room-id" {
+		t.Errorf("expected scene attributes to be carried over, got %+v", upgraded)
+	}
+}
+
+func TestSceneResource_UpgradeStateV0DuplicateTarget(t *testing.T) {
+	ctx := context.Background()
+	r := &SceneResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	action := sceneActionModelV0{
+		TargetId:   types.StringValue("light-a"),
+		TargetType: types.StringValue("light"),
+		Brightness: types.Float64Value(100),
+		On:         types.BoolValue(true),
+	}
+	diags := prior.Set(ctx, &sceneResourceModelV0{
+		Id:      types.StringValue("scene-id"),
+		Type:    types.StringValue("scene"),
+		Name:    types.StringValue("Bedroom Reading"),
+		Actions: []sceneActionModelV0{action, action},
+		Group: &ResourceReference{
+			Rid:   types.StringValue("room-id"),
+			Rtype: types.StringValue("room"),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error setting prior state: %v", diags)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error upgrading a scene with two actions for the same target")
+	}
+}
@@ -0,0 +1,424 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/client"
+	"github.com/richseviora/huego/pkg/resources/common"
+	"github.com/richseviora/huego/pkg/resources/smart_scene"
+	"regexp"
+	"terraform-provider-philips/internal/provider/device"
+	"terraform-provider-philips/internal/provider/huetypes"
+)
+
+var _ resource.Resource = &SmartSceneResource{}
+var _ resource.ResourceWithImportState = &SmartSceneResource{}
+var _ resource.ResourceWithConfigure = &SmartSceneResource{}
+var _ resource.ResourceWithValidateConfig = &SmartSceneResource{}
+
+var timeOfDayRegex = regexp.MustCompile(`^([01]\d|2[0-3]):([0-5]\d)(:([0-5]\d))?$`)
+
+func NewSmartSceneResource() resource.Resource {
+	return &SmartSceneResource{}
+}
+
+type SmartSceneResource struct {
+	client device.ClientWithLightIDCache
+}
+
+type SmartSceneTimeslotModel struct {
+	StartTime types.String `tfsdk:"start_time"`
+	SceneID   types.String `tfsdk:"scene_id"`
+}
+
+type SmartSceneWeekTimeslotModel struct {
+	Recurrence []types.String            `tfsdk:"recurrence"`
+	Timeslots  []SmartSceneTimeslotModel `tfsdk:"timeslots"`
+}
+
+type SmartSceneResourceModel struct {
+	Id                 types.String                  `tfsdk:"id"`
+	Name               types.String                  `tfsdk:"name"`
+	Group              *ResourceReference            `tfsdk:"group"`
+	WeekTimeslots      []SmartSceneWeekTimeslotModel `tfsdk:"week_timeslots"`
+	TransitionDuration types.Int64                   `tfsdk:"transition_duration"`
+	Active             types.Bool                    `tfsdk:"active"`
+}
+
+func (s *SmartSceneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smart_scene"
+}
+
+func (s *SmartSceneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A representation of a Philips Hue smart scene, which switches between scenes by time of day and weekday.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the smart scene in the Hue Bridge.",
+			},
+			"group": schema.ObjectAttribute{
+				Required:    true,
+				Description: "The room or zone this smart scene belongs to.",
+				AttributeTypes: map[string]attr.Type{
+					"id":   types.StringType,
+					"type": types.StringType,
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"week_timeslots": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The schedules of the smart scene. Each weekday can only be part of one schedule.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"recurrence": schema.SetAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Description: "The weekdays the schedule applies to.",
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(stringvalidator.OneOf(huetypes.Weekdays...)),
+							},
+						},
+						"timeslots": schema.ListNestedAttribute{
+							Required:    true,
+							Description: "The scenes to switch to during the day, in order of their start time.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"start_time": schema.StringAttribute{
+										Required:    true,
+										Description: "The time of day the scene starts, as `HH:MM` or `HH:MM:SS`.",
+										Validators: []validator.String{
+											stringvalidator.RegexMatches(timeOfDayRegex, "must be a time of day as HH:MM or HH:MM:SS"),
+										},
+									},
+									"scene_id": schema.StringAttribute{
+										Required:    true,
+										Description: "The ID of the scene to recall at the start time.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"transition_duration": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The transition duration in milliseconds when the smart scene switches between scenes.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the smart scene is active. Smart scenes are created inactive unless this is set.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (s *SmartSceneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(device.ClientWithLightIDCache)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected device.ClientWithLightIDCache, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	s.client = client
+}
+
+func (s *SmartSceneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SmartSceneResourceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		// Values that are unknown until apply are validated once they are known.
+		return
+	}
+	resp.Diagnostics.Append(validateSmartSceneRecurrence(data.WeekTimeslots)...)
+}
+
+// validateSmartSceneRecurrence checks that each weekday is part of at most one schedule.
+func validateSmartSceneRecurrence(weekTimeslots []SmartSceneWeekTimeslotModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	seen := map[string]int{}
+	for i, weekTimeslot := range weekTimeslots {
+		for _, day := range weekTimeslot.Recurrence {
+			if day.IsUnknown() || day.IsNull() {
+				continue
+			}
+			if previous, ok := seen[day.ValueString()]; ok {
+				diags.AddAttributeError(path.Root("week_timeslots").AtListIndex(i).AtName("recurrence"), "Invalid Smart Scene Schedule",
+					fmt.Sprintf("%s is already part of week_timeslots[%d]. Each weekday can only be part of one schedule.", day.ValueString(), previous))
+				continue
+			}
+			seen[day.ValueString()] = i
+		}
+	}
+	return diags
+}
+
+func (s *SmartSceneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SmartSceneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createObj := smart_scene.SmartSceneCreate{
+		Metadata: smart_scene.Metadata{
+			Name: data.Name.ValueString(),
+		},
+		Group: common.Reference{
+			RID:   data.Group.Rid.ValueString(),
+			RType: data.Group.Rtype.ValueString(),
+		},
+		WeekTimeslots:      createSmartSceneWeekTimeslotsObj(data.WeekTimeslots),
+		TransitionDuration: smartSceneTransitionDuration(data.TransitionDuration),
+	}
+	if data.Active.ValueBool() {
+		createObj.Recall = &smart_scene.Recall{Action: "activate"}
+	}
+	newObj, err := s.client.SmartSceneService().CreateSmartScene(ctx, createObj)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating smart scene",
+			"Could not create smart scene: "+err.Error(),
+		)
+		return
+	}
+	data.Id = types.StringValue(newObj.RID)
+	data.Active = types.BoolValue(data.Active.ValueBool())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *SmartSceneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SmartSceneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := s.client.SmartSceneService().GetSmartScene(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading smart scene",
+			"Could not read smart scene ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(result.Metadata.Name)
+	data.Group = &ResourceReference{
+		Rid:   types.StringValue(result.Group.RID),
+		Rtype: types.StringValue(result.Group.RType),
+	}
+	weekTimeslots, diags := createSmartSceneWeekTimeslotsModel(result.WeekTimeslots, data.WeekTimeslots)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.WeekTimeslots = weekTimeslots
+	data.Active = types.BoolValue(result.State == "active")
+	// The transition duration is only tracked once it is managed by Terraform.
+	if !data.TransitionDuration.IsNull() {
+		data.TransitionDuration = types.Int64Value(int64(result.TransitionDuration))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *SmartSceneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SmartSceneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update := smart_scene.SmartSceneUpdate{
+		Metadata: &smart_scene.Metadata{
+			Name: data.Name.ValueString(),
+		},
+		WeekTimeslots:      createSmartSceneWeekTimeslotsObj(data.WeekTimeslots),
+		TransitionDuration: smartSceneTransitionDuration(data.TransitionDuration),
+	}
+	// Only a change of the active state recalls the smart scene, so an update does not interrupt it.
+	if !data.Active.IsUnknown() && !data.Active.Equal(state.Active) {
+		action := "deactivate"
+		if data.Active.ValueBool() {
+			action = "activate"
+		}
+		update.Recall = &smart_scene.Recall{Action: action}
+	}
+	_, err := s.client.SmartSceneService().UpdateSmartScene(ctx, data.Id.ValueString(), update)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating smart scene",
+			"Could not update smart scene ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if data.Active.IsUnknown() {
+		data.Active = state.Active
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (s *SmartSceneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SmartSceneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := s.client.SmartSceneService().DeleteSmartScene(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error deleting smart scene",
+			"Could not delete smart scene ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+func (s *SmartSceneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func smartSceneTransitionDuration(duration types.Int64) *int {
+	if duration.IsNull() || duration.IsUnknown() {
+		return nil
+	}
+	result := int(duration.ValueInt64())
+	return &result
+}
+
+func createSmartSceneWeekTimeslotsObj(weekTimeslots []SmartSceneWeekTimeslotModel) []smart_scene.WeekTimeslot {
+	result := make([]smart_scene.WeekTimeslot, len(weekTimeslots))
+	for i, weekTimeslot := range weekTimeslots {
+		recurrence := make([]string, len(weekTimeslot.Recurrence))
+		for j, day := range weekTimeslot.Recurrence {
+			recurrence[j] = day.ValueString()
+		}
+		timeslots := make([]smart_scene.Timeslot, len(weekTimeslot.Timeslots))
+		for j, timeslot := range weekTimeslot.Timeslots {
+			timeslots[j] = smart_scene.Timeslot{
+				StartTime: smart_scene.StartTime{
+					Kind: "time",
+					Time: parseTimeOfDay(timeslot.StartTime.ValueString()),
+				},
+				Target: common.Reference{
+					RID:   timeslot.SceneID.ValueString(),
+					RType: "scene",
+				},
+			}
+		}
+		result[i] = smart_scene.WeekTimeslot{
+			Timeslots:  timeslots,
+			Recurrence: recurrence,
+		}
+	}
+	return result
+}
+
+// createSmartSceneWeekTimeslotsModel maps the schedules read from the bridge. Start times keep the
+// format of the prior state when they describe the same time of day. Time slots that start at sunrise or
+// sunset, which can only be set up in the Hue app, are reported as errors.
+func createSmartSceneWeekTimeslotsModel(weekTimeslots []smart_scene.WeekTimeslot, prior []SmartSceneWeekTimeslotModel) ([]SmartSceneWeekTimeslotModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := make([]SmartSceneWeekTimeslotModel, len(weekTimeslots))
+	for i, weekTimeslot := range weekTimeslots {
+		recurrence := make([]types.String, len(weekTimeslot.Recurrence))
+		for j, day := range weekTimeslot.Recurrence {
+			recurrence[j] = types.StringValue(day)
+		}
+		timeslots := make([]SmartSceneTimeslotModel, len(weekTimeslot.Timeslots))
+		for j, timeslot := range weekTimeslot.Timeslots {
+			if timeslot.StartTime.Kind != "time" || timeslot.StartTime.Time == nil {
+				diags.AddAttributeError(
+					path.Root("week_timeslots").AtListIndex(i).AtName("timeslots").AtListIndex(j).AtName("start_time"),
+					"Unsupported Smart Scene Time Slot",
+					fmt.Sprintf("Time slot %d of week time slot %d starts at %q, but only fixed times of day are supported.", j, i, timeslot.StartTime.Kind))
+				continue
+			}
+			startTime := types.StringValue(formatTimeOfDay(timeslot.StartTime.Time))
+			if i < len(prior) && j < len(prior[i].Timeslots) {
+				priorTime := prior[i].Timeslots[j].StartTime.ValueString()
+				if formatTimeOfDay(parseTimeOfDay(priorTime)) == startTime.ValueString() {
+					startTime = types.StringValue(priorTime)
+				}
+			}
+			timeslots[j] = SmartSceneTimeslotModel{
+				StartTime: startTime,
+				SceneID:   types.StringValue(timeslot.Target.RID),
+			}
+		}
+		result[i] = SmartSceneWeekTimeslotModel{
+			Recurrence: recurrence,
+			Timeslots:  timeslots,
+		}
+	}
+	return result, diags
+}
+
+// parseTimeOfDay parses a time of day as HH:MM or HH:MM:SS. The format is enforced by the schema.
+func parseTimeOfDay(value string) *smart_scene.TimeOfDay {
+	result := &smart_scene.TimeOfDay{}
+	_, _ = fmt.Sscanf(value, "%d:%d:%d", &result.Hour, &result.Minute, &result.Second)
+	return result
+}
+
+func formatTimeOfDay(t *smart_scene.TimeOfDay) string {
+	if t == nil {
+		return ""
+	}
+	if t.Second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/richseviora/huego/pkg/resources/smart_scene"
+)
+
+func TestSmartSceneTimeOfDay(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected smart_scene.TimeOfDay
+		format   string
+	}{
+		{value: "07:30", expected: smart_scene.TimeOfDay{Hour: 7, Minute: 30}, format: "07:30"},
+		{value: "07:30:00", expected: smart_scene.TimeOfDay{Hour: 7, Minute: 30}, format: "07:30"},
+		{value: "23:59:30", expected: smart_scene.TimeOfDay{Hour: 23, Minute: 59, Second: 30}, format: "23:59:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got := parseTimeOfDay(tt.value)
+			if *got != tt.expected {
+				t.Errorf("parseTimeOfDay(%q) = %+v, want %+v", tt.value, *got, tt.expected)
+			}
+			if formatted := formatTimeOfDay(got); formatted != tt.format {
+				t.Errorf("formatTimeOfDay(%+v) = %q, want %q", *got, formatted, tt.format)
+			}
+		})
+	}
+}
+
+func TestSmartSceneWeekTimeslotsModelKeepsStartTimeFormat(t *testing.T) {
+	read := []smart_scene.WeekTimeslot{{
+		Recurrence: []string{"monday"},
+		Timeslots: []smart_scene.Timeslot{
+			{StartTime: smart_scene.StartTime{Kind: "time", Time: &smart_scene.TimeOfDay{Hour: 7}}},
+			{StartTime: smart_scene.StartTime{Kind: "time", Time: &smart_scene.TimeOfDay{Hour: 21}}},
+		},
+	}}
+	prior := []SmartSceneWeekTimeslotModel{{
+		Timeslots: []SmartSceneTimeslotModel{
+			{StartTime: types.StringValue("07:00:00")},
+			{StartTime: types.StringValue("20:00")},
+		},
+	}}
+
+	result, diags := createSmartSceneWeekTimeslotsModel(read, prior)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if got := result[0].Timeslots[0].StartTime.ValueString(); got != "07:00:00" {
+		t.Errorf("expected the prior format to be kept for the same time, got %q", got)
+	}
+	if got := result[0].Timeslots[1].StartTime.ValueString(); got != "21:00" {
+		t.Errorf("expected a changed time to be read from the bridge, got %q", got)
+	}
+}
+
+func TestSmartSceneWeekTimeslotsModelRejectsSolarStartTimes(t *testing.T) {
+	read := []smart_scene.WeekTimeslot{{
+		Recurrence: []string{"monday"},
+		Timeslots: []smart_scene.Timeslot{
+			{StartTime: smart_scene.StartTime{Kind: "time", Time: &smart_scene.TimeOfDay{Hour: 7}}},
+			{StartTime: smart_scene.StartTime{Kind: "sunset"}},
+		},
+	}}
+
+	_, diags := createSmartSceneWeekTimeslotsModel(read, nil)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error for the sunset time slot, got %v", diags)
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), `"sunset"`) {
+		t.Errorf("expected the error to name the start time, got %q", diags.Errors()[0].Detail())
+	}
+}
+
+func TestValidateSmartSceneRecurrence(t *testing.T) {
+	days := func(values ...string) []types.String {
+		result := make([]types.String, len(values))
+		for i, v := range values {
+			result[i] = types.StringValue(v)
+		}
+		return result
+	}
+
+	valid := []SmartSceneWeekTimeslotModel{
+		{Recurrence: days("monday", "tuesday", "wednesday", "thursday", "friday")},
+		{Recurrence: days("saturday", "sunday")},
+	}
+	if diags := validateSmartSceneRecurrence(valid); diags.HasError() {
+		t.Errorf("unexpected errors for distinct weekdays: %v", diags)
+	}
+
+	overlapping := []SmartSceneWeekTimeslotModel{
+		{Recurrence: days("monday", "tuesday")},
+		{Recurrence: days("tuesday", "sunday")},
+	}
+	if diags := validateSmartSceneRecurrence(overlapping); !diags.HasError() {
+		t.Error("expected an error for a weekday in two schedules")
+	}
+}